@@ -0,0 +1,634 @@
+package openhue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// BACKUP / RESTORE
+//--------------------------------------------------------------------------------------------------------------------//
+
+// Backup is the user configuration of a bridge: the names of the devices, the rooms, the zones, the scenes and the
+// smart scenes. It can be restored onto another bridge, for instance when replacing a bridge, see PlanRestore.
+type Backup struct {
+	CreatedAt   time.Time       `json:"created_at"`
+	BridgeId    string          `json:"bridge_id,omitempty"`
+	Devices     []BackupDevice  `json:"devices"`
+	Rooms       []RoomGet       `json:"rooms"`
+	Zones       []RoomGet       `json:"zones"`
+	Scenes      []SceneGet      `json:"scenes"`
+	SmartScenes []SmartSceneGet `json:"smart_scenes"`
+}
+
+// BackupDevice is a device along with its serial, the MAC address of its Zigbee radio, which is used to recognize
+// the device once it has been paired with another bridge.
+type BackupDevice struct {
+	DeviceGet
+	Serial string `json:"serial,omitempty"`
+}
+
+// Backup fetches the user configuration of the bridge.
+func (h *Home) Backup(ctx context.Context) (*Backup, error) {
+
+	bridge, err := h.GetBridge(ctx)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := h.GetDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	connectivities, err := h.GetZigbeeConnectivities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := h.GetRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := h.GetZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scenes, err := h.GetScenes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	smartScenes, err := h.GetSmartScenes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Backup{
+		CreatedAt:   time.Now(),
+		Rooms:       sortedValues(rooms),
+		Zones:       sortedValues(zones),
+		Scenes:      sortedValues(scenes),
+		SmartScenes: sortedValues(smartScenes),
+	}
+	if bridge.BridgeId != nil {
+		b.BridgeId = *bridge.BridgeId
+	}
+
+	serials := deviceSerials(connectivities)
+	for _, id := range sortedIds(devices) {
+		b.Devices = append(b.Devices, BackupDevice{DeviceGet: devices[id], Serial: serials[id]})
+	}
+
+	return b, nil
+}
+
+// ParseBackup decodes a Backup from its JSON representation.
+func ParseBackup(data []byte) (*Backup, error) {
+	var b Backup
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("unable to parse backup: %w", err)
+	}
+	return &b, nil
+}
+
+// JSON encodes the backup as indented JSON.
+func (b *Backup) JSON() ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
+
+// RestoreAction is the kind of change a RestoreStep makes to the bridge.
+type RestoreAction string
+
+const (
+	RestoreRenameDevice     RestoreAction = "rename_device"
+	RestoreCreateRoom       RestoreAction = "create_room"
+	RestoreCreateZone       RestoreAction = "create_zone"
+	RestoreCreateScene      RestoreAction = "create_scene"
+	RestoreCreateSmartScene RestoreAction = "create_smart_scene"
+)
+
+// RestorePlan describes how a Backup is going to be restored onto a bridge. Review it before calling ApplyRestore.
+type RestorePlan struct {
+	// Devices maps the IDs of the backed up devices to the IDs of the same devices on the bridge.
+	Devices map[string]string
+	// UnmatchedDevices lists the backed up devices that have not been found on the bridge.
+	UnmatchedDevices []UnmatchedDevice
+	// Steps lists the changes to make to the bridge, in order.
+	Steps []RestoreStep
+
+	// ids maps the IDs of the backup to the IDs of the bridge: devices, their services and already existing resources
+	ids map[string]string
+}
+
+// UnmatchedDevice is a backed up device that has not been found on the bridge.
+type UnmatchedDevice struct {
+	Id     string
+	Name   string
+	Reason string
+}
+
+// RestoreStep is a change made to the bridge to restore a resource of the backup.
+type RestoreStep struct {
+	Action RestoreAction
+	// OldId is the ID of the resource in the backup.
+	OldId string
+	Name  string
+	// ExistingId is the ID of a resource of the same name that already exists on the bridge. It is used in place of
+	// the backed up resource and the step is skipped.
+	ExistingId string
+	// Skipped is the reason why the step will not be applied, empty if it will.
+	Skipped string
+	// Warnings lists the parts of the resource that cannot be restored, e.g. lights that are not on the bridge.
+	Warnings []string
+
+	// apply makes the change, translating the IDs of the backup with ids, and returns the ID of the resource
+	apply func(ctx context.Context, h *Home, ids map[string]string) (string, error)
+}
+
+// PlanRestore computes how the backup would be restored onto the bridge, without changing anything. This is the
+// dry-run of ApplyRestore.
+//
+// The backed up devices are matched with the devices of the bridge by serial first, then by product data (manufacturer,
+// model and product name) when there is a single candidate, or a single one with the same name. Rooms, zones, scenes
+// and smart scenes that already exist on the bridge with the same name are not duplicated.
+//
+// Example:
+//
+//	plan, err := home.PlanRestore(ctx, backup)
+//	fmt.Print(plan)
+//	ids, err := home.ApplyRestore(ctx, plan)
+func (h *Home) PlanRestore(ctx context.Context, b *Backup) (*RestorePlan, error) {
+
+	devices, err := h.GetDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	connectivities, err := h.GetZigbeeConnectivities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := h.GetRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := h.GetZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scenes, err := h.GetScenes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	smartScenes, err := h.GetSmartScenes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &RestorePlan{Devices: make(map[string]string), ids: make(map[string]string)}
+	p.matchDevices(b.Devices, devices, deviceSerials(connectivities))
+
+	// old IDs of the resources that will exist on the bridge once the previous steps are applied
+	planned := make(map[string]bool)
+	for id := range p.ids {
+		planned[id] = true
+	}
+
+	for _, old := range b.Devices {
+		if old.Id == nil || old.Metadata == nil {
+			continue
+		}
+		newId, ok := p.Devices[*old.Id]
+		if !ok {
+			continue
+		}
+		current := devices[newId]
+		if current.Metadata != nil && equalPtr(old.Metadata.Name, current.Metadata.Name) &&
+			equalPtr(old.Metadata.Archetype, current.Metadata.Archetype) {
+			continue
+		}
+		p.Steps = append(p.Steps, p.renameDeviceStep(old))
+	}
+
+	for _, room := range b.Rooms {
+		p.Steps = append(p.Steps, p.groupStep(RestoreCreateRoom, room, rooms, planned))
+	}
+	for _, zone := range b.Zones {
+		p.Steps = append(p.Steps, p.groupStep(RestoreCreateZone, zone, zones, planned))
+	}
+	for _, scene := range b.Scenes {
+		p.Steps = append(p.Steps, p.sceneStep(scene, scenes, planned))
+	}
+	for _, smartScene := range b.SmartScenes {
+		p.Steps = append(p.Steps, p.smartSceneStep(smartScene, smartScenes, planned))
+	}
+
+	return p, nil
+}
+
+// ApplyRestore applies the steps of the plan that are not skipped, in order. It returns the IDs of the restored
+// resources on the bridge, indexed by their ID in the backup. If a step fails, the restore stops and the IDs of the
+// resources restored so far are returned along with the error.
+func (h *Home) ApplyRestore(ctx context.Context, p *RestorePlan) (map[string]string, error) {
+
+	ids := make(map[string]string, len(p.ids))
+	for old, id := range p.ids {
+		ids[old] = id
+	}
+
+	for _, step := range p.Steps {
+		if step.Skipped != "" {
+			continue
+		}
+		id, err := step.apply(ctx, h, ids)
+		if err != nil {
+			return ids, fmt.Errorf("unable to %s \"%s\": %w", strings.ReplaceAll(string(step.Action), "_", " "), step.Name, err)
+		}
+		ids[step.OldId] = id
+	}
+
+	return ids, nil
+}
+
+// String renders the plan in a human-readable form.
+func (p *RestorePlan) String() string {
+	var sb strings.Builder
+
+	for _, u := range p.UnmatchedDevices {
+		fmt.Fprintf(&sb, "! device \"%s\" (%s) not found: %s\n", u.Name, u.Id, u.Reason)
+	}
+
+	for _, step := range p.Steps {
+		switch {
+		case step.Skipped != "":
+			fmt.Fprintf(&sb, "- %s \"%s\" skipped: %s\n", step.Action, step.Name, step.Skipped)
+		default:
+			fmt.Fprintf(&sb, "+ %s \"%s\"\n", step.Action, step.Name)
+		}
+		for _, w := range step.Warnings {
+			fmt.Fprintf(&sb, "  ! %s\n", w)
+		}
+	}
+
+	return sb.String()
+}
+
+// matchDevices maps the backed up devices to the devices of the bridge, along with their services.
+func (p *RestorePlan) matchDevices(backedUp []BackupDevice, devices map[string]DeviceGet, serials map[string]string) {
+
+	bySerial := make(map[string]string)
+	for id, serial := range serials {
+		bySerial[strings.ToLower(serial)] = id
+	}
+
+	matched := make(map[string]bool)
+	match := func(old BackupDevice, newId string) {
+		p.Devices[*old.Id] = newId
+		matched[newId] = true
+		p.ids[*old.Id] = newId
+		mapServices(old.DeviceGet, devices[newId], p.ids)
+	}
+
+	var remaining []BackupDevice
+	for _, old := range backedUp {
+		// backups are user supplied files
+		if old.Id == nil {
+			p.UnmatchedDevices = append(p.UnmatchedDevices, UnmatchedDevice{Name: deviceName(old.DeviceGet), Reason: "it has no ID"})
+			continue
+		}
+		if newId, ok := bySerial[strings.ToLower(old.Serial)]; ok && old.Serial != "" {
+			match(old, newId)
+			continue
+		}
+		remaining = append(remaining, old)
+	}
+
+	for _, old := range remaining {
+		name := deviceName(old.DeviceGet)
+
+		var candidates []string
+		for id, device := range devices {
+			if !matched[id] && sameProduct(old.ProductData, device.ProductData) {
+				candidates = append(candidates, id)
+			}
+		}
+		if len(candidates) > 1 {
+			var sameName []string
+			for _, id := range candidates {
+				if strings.EqualFold(deviceName(devices[id]), name) {
+					sameName = append(sameName, id)
+				}
+			}
+			candidates = sameName
+		}
+
+		switch len(candidates) {
+		case 1:
+			match(old, candidates[0])
+		case 0:
+			p.UnmatchedDevices = append(p.UnmatchedDevices, UnmatchedDevice{Id: *old.Id, Name: name, Reason: "no device with the same serial or product"})
+		default:
+			p.UnmatchedDevices = append(p.UnmatchedDevices, UnmatchedDevice{Id: *old.Id, Name: name, Reason: "several devices of the same product"})
+		}
+	}
+}
+
+func (p *RestorePlan) renameDeviceStep(old BackupDevice) RestoreStep {
+
+	body := DevicePut{Metadata: &struct {
+		Archetype *ProductArchetype `json:"archetype,omitempty"`
+		Name      *string           `json:"name,omitempty"`
+	}{Archetype: old.Metadata.Archetype, Name: old.Metadata.Name}}
+
+	return RestoreStep{
+		Action: RestoreRenameDevice,
+		OldId:  *old.Id,
+		Name:   deviceName(old.DeviceGet),
+		apply: func(ctx context.Context, h *Home, ids map[string]string) (string, error) {
+			return ids[*old.Id], h.UpdateDevice(ctx, ids[*old.Id], body)
+		},
+	}
+}
+
+func (p *RestorePlan) groupStep(action RestoreAction, group RoomGet, existing map[string]RoomGet, planned map[string]bool) RestoreStep {
+
+	step := RestoreStep{Action: action}
+	if n := groupName(group); n != nil {
+		step.Name = *n
+	}
+
+	// backups are user supplied files
+	if group.Id == nil {
+		step.Skipped = "it has no ID"
+		return step
+	}
+	step.OldId = *group.Id
+
+	for id, g := range existing {
+		if n := groupName(g); n != nil && strings.EqualFold(*n, step.Name) {
+			step.ExistingId = id
+			step.Skipped = "already exists"
+			p.ids[*group.Id] = id
+			planned[*group.Id] = true
+			return step
+		}
+	}
+
+	var children []ResourceIdentifier
+	if group.Children != nil {
+		for _, child := range *group.Children {
+			if child.Rid == nil || !planned[*child.Rid] {
+				step.Warnings = append(step.Warnings, fmt.Sprintf("child %s not found", safeRid(&child)))
+				continue
+			}
+			children = append(children, child)
+		}
+	}
+	planned[*group.Id] = true
+
+	step.apply = func(ctx context.Context, h *Home, ids map[string]string) (string, error) {
+		translated := translateRefs(children, ids)
+		body := RoomPut{Children: &translated, Metadata: group.Metadata}
+		var created *ResourceIdentifier
+		var err error
+		if action == RestoreCreateZone {
+			created, err = h.CreateZone(ctx, body)
+		} else {
+			created, err = h.CreateRoom(ctx, body)
+		}
+		if err != nil {
+			return "", err
+		}
+		return *created.Rid, nil
+	}
+
+	return step
+}
+
+func (p *RestorePlan) sceneStep(scene SceneGet, existing map[string]SceneGet, planned map[string]bool) RestoreStep {
+
+	step := RestoreStep{Action: RestoreCreateScene}
+	if scene.Metadata != nil && scene.Metadata.Name != nil {
+		step.Name = *scene.Metadata.Name
+	}
+
+	// backups are user supplied files
+	if scene.Id == nil {
+		step.Skipped = "it has no ID"
+		return step
+	}
+	step.OldId = *scene.Id
+
+	if scene.Group == nil || scene.Group.Rid == nil || !planned[*scene.Group.Rid] {
+		step.Skipped = "its room or zone is not restored"
+		return step
+	}
+
+	if groupId, ok := p.ids[*scene.Group.Rid]; ok {
+		if id := findSceneId(existing, groupId, step.Name); id != "" {
+			step.ExistingId = id
+			step.Skipped = "already exists"
+			p.ids[*scene.Id] = id
+			planned[*scene.Id] = true
+			return step
+		}
+	}
+
+	var actions []ActionGet
+	if scene.Actions != nil {
+		for _, a := range *scene.Actions {
+			if a.Target == nil || a.Target.Rid == nil || !planned[*a.Target.Rid] {
+				step.Warnings = append(step.Warnings, fmt.Sprintf("light %s not found", safeRid(a.Target)))
+				continue
+			}
+			actions = append(actions, a)
+		}
+	}
+	if len(actions) == 0 {
+		step.Skipped = "none of its lights is on the bridge"
+		return step
+	}
+	planned[*scene.Id] = true
+
+	step.apply = func(ctx context.Context, h *Home, ids map[string]string) (string, error) {
+		var metadata SceneMetadata
+		if scene.Metadata != nil {
+			metadata = SceneMetadata{Name: scene.Metadata.Name, Appdata: scene.Metadata.Appdata, Image: scene.Metadata.Image}
+		}
+		sceneType := ScenePostTypeScene
+		body := ScenePost{
+			Type:        &sceneType,
+			Group:       translateRef(*scene.Group, ids),
+			Metadata:    metadata,
+			Palette:     scene.Palette,
+			Speed:       scene.Speed,
+			AutoDynamic: scene.AutoDynamic,
+		}
+		for _, a := range actions {
+			body.Actions = append(body.Actions, a.retarget(ids[*a.Target.Rid]))
+		}
+		created, err := h.CreateScene(ctx, body)
+		if err != nil {
+			return "", err
+		}
+		return *created.Rid, nil
+	}
+
+	return step
+}
+
+func (p *RestorePlan) smartSceneStep(smartScene SmartSceneGet, existing map[string]SmartSceneGet, planned map[string]bool) RestoreStep {
+
+	step := RestoreStep{Action: RestoreCreateSmartScene}
+	if smartScene.Metadata.Name != nil {
+		step.Name = *smartScene.Metadata.Name
+	}
+
+	// backups are user supplied files
+	if smartScene.Id == nil {
+		step.Skipped = "it has no ID"
+		return step
+	}
+	step.OldId = *smartScene.Id
+
+	if smartScene.Group.Rid == nil || !planned[*smartScene.Group.Rid] {
+		step.Skipped = "its room or zone is not restored"
+		return step
+	}
+
+	if groupId, ok := p.ids[*smartScene.Group.Rid]; ok {
+		for id, s := range existing {
+			if s.Group.Rid != nil && *s.Group.Rid == groupId && s.Metadata.Name != nil && *s.Metadata.Name == step.Name {
+				step.ExistingId = id
+				step.Skipped = "already exists"
+				p.ids[*smartScene.Id] = id
+				planned[*smartScene.Id] = true
+				return step
+			}
+		}
+	}
+
+	for _, day := range smartScene.WeekTimeslots {
+		for _, slot := range day.Timeslots {
+			if slot.Target.Rid == nil || !planned[*slot.Target.Rid] {
+				step.Skipped = fmt.Sprintf("scene %s is not restored", safeRid(&slot.Target))
+				return step
+			}
+		}
+	}
+	planned[*smartScene.Id] = true
+
+	step.apply = func(ctx context.Context, h *Home, ids map[string]string) (string, error) {
+		body := SmartScenePost{
+			Group:              translateRef(smartScene.Group, ids),
+			Metadata:           smartScene.Metadata,
+			TransitionDuration: &smartScene.TransitionDuration,
+		}
+		for _, day := range smartScene.WeekTimeslots {
+			translated := DayTimeslotsGet{Recurrence: day.Recurrence}
+			for _, slot := range day.Timeslots {
+				slot.Target = translateRef(slot.Target, ids)
+				translated.Timeslots = append(translated.Timeslots, slot)
+			}
+			body.WeekTimeslots = append(body.WeekTimeslots, translated)
+		}
+		created, err := h.CreateSmartScene(ctx, body)
+		if err != nil {
+			return "", err
+		}
+		return *created.Rid, nil
+	}
+
+	return step
+}
+
+// mapServices maps the services of a backed up device to the services of the same type of the new device, in order.
+func mapServices(old, new DeviceGet, ids map[string]string) {
+	if old.Services == nil || new.Services == nil {
+		return
+	}
+
+	byType := make(map[ResourceIdentifierRtype][]string)
+	for _, s := range *new.Services {
+		if s.Rid != nil && s.Rtype != nil {
+			byType[*s.Rtype] = append(byType[*s.Rtype], *s.Rid)
+		}
+	}
+
+	for _, s := range *old.Services {
+		if s.Rid == nil || s.Rtype == nil || len(byType[*s.Rtype]) == 0 {
+			continue
+		}
+		ids[*s.Rid] = byType[*s.Rtype][0]
+		byType[*s.Rtype] = byType[*s.Rtype][1:]
+	}
+}
+
+// deviceSerials indexes the MAC addresses of the Zigbee radios by the ID of the device they belong to.
+func deviceSerials(connectivities map[string]ZigbeeConnectivityGet) map[string]string {
+	serials := make(map[string]string)
+	for _, c := range connectivities {
+		if c.Owner != nil && c.Owner.Rid != nil && c.MacAddress != nil {
+			serials[*c.Owner.Rid] = *c.MacAddress
+		}
+	}
+	return serials
+}
+
+func sameProduct(a, b *ProductData) bool {
+	if a == nil || b == nil || a.ModelId == nil {
+		return false
+	}
+	return equalPtr(a.ModelId, b.ModelId) &&
+		equalPtr(a.ManufacturerName, b.ManufacturerName) &&
+		equalPtr(a.ProductName, b.ProductName)
+}
+
+func deviceName(d DeviceGet) string {
+	if d.Metadata == nil || d.Metadata.Name == nil {
+		return ""
+	}
+	return *d.Metadata.Name
+}
+
+// equalPtr returns true if both pointers are nil, or if they point at equal values.
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func translateRef(ref ResourceIdentifier, ids map[string]string) ResourceIdentifier {
+	if ref.Rid == nil {
+		return ref
+	}
+	if id, ok := ids[*ref.Rid]; ok {
+		ref.Rid = &id
+	}
+	return ref
+}
+
+func translateRefs(refs []ResourceIdentifier, ids map[string]string) []ResourceIdentifier {
+	translated := make([]ResourceIdentifier, 0, len(refs))
+	for _, ref := range refs {
+		translated = append(translated, translateRef(ref, ids))
+	}
+	return translated
+}
+
+func safeRid(ref *ResourceIdentifier) string {
+	if ref == nil || ref.Rid == nil {
+		return "<unknown>"
+	}
+	return *ref.Rid
+}
+
+// sortedValues returns the values of a map of resources, sorted by ID.
+func sortedValues[T any](resources map[string]T) []T {
+	ids := sortedIds(resources)
+	values := make([]T, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, resources[id])
+	}
+	return values
+}
@@ -0,0 +1,198 @@
+package openhue
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testBackup(t *testing.T) *Backup {
+	return fromJSON[*Backup](t, `{
+		"devices": [
+			{"id": "dev-a", "serial": "00:17:88:01:aa", "metadata": {"name": "Ceiling"},
+			 "product_data": {"model_id": "LCA001", "manufacturer_name": "Signify", "product_name": "Hue color lamp"},
+			 "services": [{"rid": "light-a", "rtype": "light"}]},
+			{"id": "dev-b", "metadata": {"name": "Desk"},
+			 "product_data": {"model_id": "LTW001", "manufacturer_name": "Signify", "product_name": "Hue white lamp"},
+			 "services": [{"rid": "light-b", "rtype": "light"}]},
+			{"id": "dev-c", "metadata": {"name": "Plug"},
+			 "product_data": {"model_id": "LOM001", "manufacturer_name": "Signify", "product_name": "Hue smart plug"},
+			 "services": [{"rid": "light-c", "rtype": "light"}]}
+		],
+		"rooms": [
+			{"id": "room-1", "metadata": {"name": "Kitchen", "archetype": "kitchen"},
+			 "children": [{"rid": "dev-a", "rtype": "device"}, {"rid": "dev-c", "rtype": "device"}]}
+		],
+		"zones": [],
+		"scenes": [
+			{"id": "scene-1", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Relax"}, "actions": [
+				{"target": {"rid": "light-a", "rtype": "light"}, "action": {"on": {"on": true}}},
+				{"target": {"rid": "light-c", "rtype": "light"}, "action": {"on": {"on": true}}}
+			]},
+			{"id": "scene-2", "group": {"rid": "room-9", "rtype": "room"}, "metadata": {"name": "Orphan"}, "actions": [
+				{"target": {"rid": "light-a", "rtype": "light"}, "action": {"on": {"on": true}}}
+			]}
+		],
+		"smart_scenes": [
+			{"id": "smart-1", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Natural light"},
+			 "state": "inactive", "transition_duration": 60000, "week_timeslots": [
+				{"recurrence": ["monday"], "timeslots": [
+					{"start_time": {"kind": "time", "time": {"hour": 7, "minute": 0}}, "target": {"rid": "scene-1", "rtype": "scene"}}
+				]}
+			]}
+		]
+	}`)
+}
+
+func mockNewBridge(t *testing.T, m *ClientWithResponsesMock) {
+	m.On("GetDevicesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetDevicesResponse](t, `[
+		{"id": "new-a", "product_data": {"model_id": "LCA001", "manufacturer_name": "Signify", "product_name": "Hue color lamp"},
+		 "services": [{"rid": "new-light-a", "rtype": "light"}]},
+		{"id": "new-b", "metadata": {"name": "Desk"},
+		 "product_data": {"model_id": "LTW001", "manufacturer_name": "Signify", "product_name": "Hue white lamp"},
+		 "services": [{"rid": "new-light-b", "rtype": "light"}]}
+	]`), nil)
+	m.On("GetZigbeeConnectivitiesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetZigbeeConnectivitiesResponse](t, `[
+		{"id": "zb-a", "mac_address": "00:17:88:01:AA", "owner": {"rid": "new-a", "rtype": "device"}}
+	]`), nil)
+	m.On("GetRoomsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetRoomsResponse](t, `[]`), nil)
+	m.On("GetZonesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetZonesResponse](t, `[]`), nil)
+	m.On("GetScenesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetScenesResponse](t, `[]`), nil)
+	m.On("GetSmartScenesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetSmartScenesResponse](t, `[]`), nil)
+}
+
+func TestPlanRestore(t *testing.T) {
+	home, m := NewTestHome()
+	mockNewBridge(t, m)
+
+	plan, err := home.PlanRestore(context.Background(), testBackup(t))
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"dev-a": "new-a", "dev-b": "new-b"}, plan.Devices)
+	assert.Equal(t, []UnmatchedDevice{{Id: "dev-c", Name: "Plug", Reason: "no device with the same serial or product"}}, plan.UnmatchedDevices)
+
+	assert.Equal(t, `! device "Plug" (dev-c) not found: no device with the same serial or product
++ rename_device "Ceiling"
++ create_room "Kitchen"
+  ! child dev-c not found
++ create_scene "Relax"
+  ! light light-c not found
+- create_scene "Orphan" skipped: its room or zone is not restored
++ create_smart_scene "Natural light"
+`, plan.String())
+
+	m.AssertNotCalled(t, "CreateRoomWithResponse", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApplyRestore(t *testing.T) {
+	home, m := NewTestHome()
+	mockNewBridge(t, m)
+
+	m.On("UpdateDeviceWithResponse", mock.Anything, "new-a", mock.Anything, mock.Anything).Return(&UpdateDeviceResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+	m.On("CreateRoomWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateRoomResponse](t, `[{"rid": "new-room", "rtype": "room"}]`), nil)
+	m.On("CreateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateSceneResponse](t, `[{"rid": "new-scene", "rtype": "scene"}]`), nil)
+	m.On("CreateSmartSceneWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateSmartSceneResponse](t, `[{"rid": "new-smart", "rtype": "smart_scene"}]`), nil)
+
+	plan, err := home.PlanRestore(context.Background(), testBackup(t))
+	assert.NoError(t, err)
+
+	ids, err := home.ApplyRestore(context.Background(), plan)
+	assert.NoError(t, err)
+	assert.Equal(t, "new-room", ids["room-1"])
+	assert.Equal(t, "new-scene", ids["scene-1"])
+	assert.Equal(t, "new-smart", ids["smart-1"])
+
+	m.AssertCalled(t, "UpdateDeviceWithResponse", mock.Anything, "new-a", mock.MatchedBy(func(body DevicePut) bool {
+		return *body.Metadata.Name == "Ceiling"
+	}), mock.Anything)
+
+	m.AssertCalled(t, "CreateRoomWithResponse", mock.Anything, mock.MatchedBy(func(body RoomPut) bool {
+		return *body.Metadata.Name == "Kitchen" && len(*body.Children) == 1 && *(*body.Children)[0].Rid == "new-a"
+	}), mock.Anything)
+
+	m.AssertCalled(t, "CreateSceneWithResponse", mock.Anything, mock.MatchedBy(func(body ScenePost) bool {
+		return *body.Type == ScenePostTypeScene && *body.Group.Rid == "new-room" &&
+			len(body.Actions) == 1 && *body.Actions[0].Target.Rid == "new-light-a"
+	}), mock.Anything)
+
+	m.AssertCalled(t, "CreateSmartSceneWithResponse", mock.Anything, mock.MatchedBy(func(body SmartScenePost) bool {
+		return *body.Group.Rid == "new-room" && *body.WeekTimeslots[0].Timeslots[0].Target.Rid == "new-scene"
+	}), mock.Anything)
+}
+
+func TestApplyRestore_IncompleteScenes(t *testing.T) {
+	home, m := NewTestHome()
+	mockNewBridge(t, m)
+
+	m.On("CreateRoomWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateRoomResponse](t, `[{"rid": "new-room", "rtype": "room"}]`), nil)
+	m.On("CreateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateSceneResponse](t, `[{"rid": "new-scene", "rtype": "scene"}]`), nil)
+
+	backup := fromJSON[*Backup](t, `{
+		"devices": [{"id": "dev-a", "serial": "00:17:88:01:aa", "services": [{"rid": "light-a", "rtype": "light"}]}],
+		"rooms": [{"id": "room-1", "metadata": {"name": "Kitchen"}, "children": [{"rid": "dev-a", "rtype": "device"}]}],
+		"scenes": [
+			{"id": "scene-1", "group": {"rid": "room-1", "rtype": "room"}, "actions": [
+				{"target": {"rid": "light-a", "rtype": "light"}, "action": {"on": {"on": true}}}
+			]},
+			{"group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "No ID"}}
+		]
+	}`)
+
+	plan, err := home.PlanRestore(context.Background(), backup)
+	assert.NoError(t, err)
+	assert.Contains(t, plan.String(), `- create_scene "No ID" skipped: it has no ID`)
+
+	ids, err := home.ApplyRestore(context.Background(), plan)
+	assert.NoError(t, err)
+	assert.Equal(t, "new-scene", ids["scene-1"])
+
+	m.AssertCalled(t, "CreateSceneWithResponse", mock.Anything, mock.MatchedBy(func(body ScenePost) bool {
+		return *body.Type == ScenePostTypeScene && body.Metadata.Name == nil
+	}), mock.Anything)
+}
+
+func TestPlanRestore_MissingIds(t *testing.T) {
+	tests := []struct {
+		name   string
+		backup string
+		want   string
+	}{
+		{"device", `{"devices": [{"serial": "00:17:88:01:aa", "metadata": {"name": "Ceiling"}}]}`,
+			`! device "Ceiling" () not found: it has no ID`},
+		{"room", `{"rooms": [{"metadata": {"name": "Kitchen"}}]}`,
+			`- create_room "Kitchen" skipped: it has no ID`},
+		{"zone", `{"zones": [{"metadata": {"name": "Downstairs"}}]}`,
+			`- create_zone "Downstairs" skipped: it has no ID`},
+		{"smart scene", `{"smart_scenes": [{"group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Natural light"}}]}`,
+			`- create_smart_scene "Natural light" skipped: it has no ID`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home, m := NewTestHome()
+			mockNewBridge(t, m)
+
+			plan, err := home.PlanRestore(context.Background(), fromJSON[*Backup](t, tt.backup))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want+"\n", plan.String())
+			assert.Empty(t, plan.Devices)
+		})
+	}
+}
+
+func TestBackup_JSONRoundTrip(t *testing.T) {
+	backup := testBackup(t)
+
+	data, err := backup.JSON()
+	assert.NoError(t, err)
+
+	parsed, err := ParseBackup(data)
+	assert.NoError(t, err)
+	assert.Equal(t, backup, parsed)
+	assert.Equal(t, "00:17:88:01:aa", parsed.Devices[0].Serial)
+}
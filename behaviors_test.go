@@ -0,0 +1,174 @@
+package openhue
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const wakeUpSchema = `{
+	"type": "object",
+	"required": ["end_brightness", "fade_in_duration", "when", "where"],
+	"additionalProperties": false,
+	"properties": {
+		"end_brightness": {"type": "number", "minimum": 1, "maximum": 100},
+		"fade_in_duration": {"$ref": "#/definitions/duration"},
+		"turn_lights_off_after": {"$ref": "#/definitions/duration"},
+		"style": {"enum": ["basic", "sunrise"]},
+		"when": {
+			"type": "object",
+			"required": ["time_point"],
+			"properties": {
+				"recurrence_days": {"type": "array", "items": {"type": "string"}},
+				"time_point": {"oneOf": [
+					{"type": "object", "required": ["type", "time"], "properties": {"type": {"const": "time"}}},
+					{"type": "object", "required": ["type"], "properties": {"type": {"const": "sunrise"}}}
+				]}
+			}
+		},
+		"where": {"type": "array", "minItems": 1, "items": {"type": "object", "required": ["group"]}}
+	},
+	"definitions": {
+		"duration": {"type": "object", "required": ["seconds"], "properties": {"seconds": {"type": "integer", "minimum": 0}}}
+	}
+}`
+
+func behaviorScriptResponse(t *testing.T) *GetBehaviorScriptResponse {
+	return dataResponse[*GetBehaviorScriptResponse](t, `[{"id": "`+WakeUpScriptId+`", "metadata": {"name": "Wake up"}, "configuration_schema": `+wakeUpSchema+`}]`)
+}
+
+func wakeUpConfig() WakeUpConfiguration {
+	return WakeUpConfiguration{
+		EndBrightness:  100,
+		FadeInDuration: BehaviorDuration{Seconds: 1800},
+		Style:          "sunrise",
+		When: BehaviorWhen{
+			RecurrenceDays: []Weekday{Monday},
+			TimePoint:      BehaviorTimePoint{Type: "time", Time: &BehaviorTime{Hour: 7}},
+		},
+		Where: []BehaviorWhere{{Group: ResourceIdentifier{Rid: ptr("room-1"), Rtype: ptr(ResourceIdentifierRtypeRoom)}}},
+	}
+}
+
+func TestValidateBehaviorConfiguration(t *testing.T) {
+	script := &(*behaviorScriptResponse(t).JSON200.Data)[0]
+
+	assert.NoError(t, ValidateBehaviorConfiguration(script, wakeUpConfig()))
+
+	err := ValidateBehaviorConfiguration(script, map[string]any{
+		"end_brightness":   150,
+		"fade_in_duration": map[string]any{"seconds": 1.5},
+		"style":            "disco",
+		"when":             map[string]any{"time_point": map[string]any{"type": "noon"}},
+		"where":            []any{},
+		"color":            "red",
+	})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.EqualError(t, err, "invalid configuration: "+
+		"color: is not allowed; "+
+		"end_brightness: 150 is greater than 100; "+
+		"fade_in_duration.seconds: expected integer, got number; "+
+		"style: disco is not one of [\"basic\",\"sunrise\"]; "+
+		"when.time_point: matches 0 of the allowed schemas instead of exactly one; "+
+		"where: has 0 items, expected at least 1")
+}
+
+func TestValidateSchema_CircularReference(t *testing.T) {
+	self := fromJSON[map[string]any](t, `{"$ref": "#"}`)
+	assert.Equal(t, []string{`(root): circular schema reference "#"`}, validateSchema(self, map[string]any{}))
+
+	ancestor := fromJSON[map[string]any](t, `{
+		"$ref": "#/definitions/a",
+		"definitions": {
+			"a": {"anyOf": [{"$ref": "#/definitions/b"}]},
+			"b": {"properties": {"c": {"$ref": "#/definitions/a"}}, "allOf": [{"$ref": "#/definitions/a"}]}
+		}
+	}`)
+	assert.Equal(t, []string{"(root): does not match any of the allowed schemas"}, validateSchema(ancestor, map[string]any{}))
+
+	// a reference to an ancestor is fine as long as it validates a nested value
+	tree := fromJSON[map[string]any](t, `{
+		"type": "object",
+		"properties": {"children": {"type": "array", "items": {"$ref": "#"}}, "name": {"type": "string"}}
+	}`)
+	assert.Empty(t, validateSchema(tree, fromJSON[map[string]any](t, `{"name": "a", "children": [{"name": "b", "children": [{"name": "c"}]}]}`)))
+	assert.Equal(t, []string{"children[0].children[0].name: expected string, got number"},
+		validateSchema(tree, fromJSON[map[string]any](t, `{"children": [{"children": [{"name": 1}]}]}`)))
+}
+
+func TestCreateBehaviorInstance(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetBehaviorScriptWithResponse", mock.Anything, WakeUpScriptId, mock.Anything).Return(behaviorScriptResponse(t), nil)
+
+	withTestServer(t, home, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/clip/v2/resource/behavior_instance", r.URL.Path)
+
+		body, _ := io.ReadAll(r.Body)
+		sent := fromJSON[map[string]any](t, string(body))
+		assert.Equal(t, WakeUpScriptId, sent["script_id"])
+		assert.Equal(t, map[string]any{"name": "Morning"}, sent["metadata"])
+		assert.Equal(t, 100.0, sent["configuration"].(map[string]any)["end_brightness"])
+
+		_, _ = w.Write([]byte(`{"data": [{"rid": "bi-1", "rtype": "behavior_instance"}], "errors": []}`))
+	})
+
+	config := wakeUpConfig()
+	id, err := home.CreateBehaviorInstance(context.Background(), config.ScriptId(), "Morning", config)
+	assert.NoError(t, err)
+	assert.Equal(t, "bi-1", id)
+}
+
+func TestUpdateBehaviorInstanceConfiguration_Invalid(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetBehaviorInstanceWithResponse", mock.Anything, "bi-1", mock.Anything).Return(dataResponse[*GetBehaviorInstanceResponse](t, `[{"id": "bi-1", "script_id": "`+WakeUpScriptId+`"}]`), nil)
+
+	m.On("GetBehaviorScriptWithResponse", mock.Anything, WakeUpScriptId, mock.Anything).Return(behaviorScriptResponse(t), nil)
+
+	err := home.UpdateBehaviorInstanceConfiguration(context.Background(), "bi-1", TimerConfiguration{})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	m.AssertNotCalled(t, "UpdateBehaviorInstanceWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEnableDisableBehaviorInstance(t *testing.T) {
+	home, m := NewTestHome()
+	m.On("UpdateBehaviorInstanceWithResponse", mock.Anything, "bi-1", mock.Anything, mock.Anything).Return(&UpdateBehaviorInstanceResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	assert.NoError(t, home.EnableBehaviorInstance(context.Background(), "bi-1"))
+	assert.NoError(t, home.DisableBehaviorInstance(context.Background(), "bi-1"))
+
+	m.AssertCalled(t, "UpdateBehaviorInstanceWithResponse", mock.Anything, "bi-1", mock.MatchedBy(func(body BehaviorInstancePut) bool {
+		return *body.Enabled
+	}), mock.Anything)
+	m.AssertCalled(t, "UpdateBehaviorInstanceWithResponse", mock.Anything, "bi-1", mock.MatchedBy(func(body BehaviorInstancePut) bool {
+		return !*body.Enabled
+	}), mock.Anything)
+}
+
+func TestBehaviorInstance_ErrAndDecode(t *testing.T) {
+	instance := fromJSON[BehaviorInstanceGet](t, `{
+		"id": "bi-1", "metadata": {"name": "Morning"}, "status": "errored", "last_error": "group not found",
+		"configuration": {"end_brightness": 80, "fade_in_duration": {"seconds": 600}, "when": {"time_point": {"type": "time", "time": {"hour": 6, "minute": 30}}}, "where": []}
+	}`)
+
+	err := instance.Err()
+	assert.ErrorIs(t, err, ErrBehaviorErrored)
+	assert.EqualError(t, err, `behavior instance "Morning": behavior instance errored: group not found`)
+
+	var config WakeUpConfiguration
+	assert.NoError(t, instance.DecodeConfiguration(&config))
+	assert.Equal(t, 80.0, config.EndBrightness)
+	assert.Equal(t, 30, config.When.TimePoint.Time.Minute)
+
+	instance.Status = ptr(Running)
+	assert.NoError(t, instance.Err())
+}
@@ -52,8 +52,42 @@ var (
 	ErrInternalServerError = errors.New("internal server error")
 	ErrServiceUnavailable  = errors.New("service unavailable")
 	ErrEmptyResponse       = errors.New("no data returned from API")
+	ErrSceneModified       = errors.New("scene was modified concurrently")
+	ErrAmbiguousName       = errors.New("ambiguous name")
+	ErrUnsupportedResource = errors.New("unsupported resource type")
+	ErrFirmwareInstall     = errors.New("firmware installation failed")
+	ErrNotConfirmed        = errors.New("action not confirmed")
+	ErrInvalidConfig       = errors.New("invalid configuration")
+	ErrBehaviorErrored     = errors.New("behavior instance errored")
+	ErrEmptyName           = errors.New("empty name")
 )
 
+// AmbiguousNameError is returned by the Find* lookup functions when a name matches several resources.
+// It matches ErrAmbiguousName with errors.Is().
+type AmbiguousNameError struct {
+	Rtype      ResourceIdentifierRtype
+	Name       string
+	Candidates []NamedResource
+}
+
+// NamedResource is a resource ID along with its human-readable name.
+type NamedResource struct {
+	Id   string
+	Name string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, c := range e.Candidates {
+		candidates = append(candidates, fmt.Sprintf("\"%s\" (%s)", c.Name, c.Id))
+	}
+	return fmt.Sprintf("ambiguous %s name \"%s\", candidates are: %s", e.Rtype, e.Name, strings.Join(candidates, ", "))
+}
+
+func (e *AmbiguousNameError) Is(target error) bool {
+	return target == ErrAmbiguousName
+}
+
 // Is implements errors.Is for ApiError, allowing checks like:
 //
 //	if errors.Is(err, openhue.ErrNotFound) { ... }
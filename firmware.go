@@ -0,0 +1,250 @@
+package openhue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// FIRMWARE UPDATE
+//--------------------------------------------------------------------------------------------------------------------//
+
+// FirmwareUpdate is the software update status of a device.
+type FirmwareUpdate struct {
+	// UpdateId is the ID of the device_software_update resource.
+	UpdateId   string
+	DeviceId   string
+	DeviceName string
+	State      DeviceSoftwareUpdateGetState
+	// Problems lists what prevents the update from being installed, as reported by the bridge.
+	Problems []string
+}
+
+// FirmwareResult is the outcome of the installation of a FirmwareUpdate.
+type FirmwareResult struct {
+	FirmwareUpdate
+	// Err is nil when the update has been installed. Failed installations wrap ErrFirmwareInstall.
+	Err error
+}
+
+type firmwareConfig struct {
+	concurrency  int
+	pollInterval time.Duration
+	onProgress   func(FirmwareUpdate)
+}
+
+// FirmwareOption is a functional option for configuring InstallFirmwareUpdates.
+type FirmwareOption func(*firmwareConfig)
+
+// WithInstallConcurrency sets how many updates are installed at the same time. It defaults to 1, in which case the
+// updates are installed one after the other, in the given order. Values lower than 1 are ignored.
+func WithInstallConcurrency(n int) FirmwareOption {
+	return func(c *firmwareConfig) {
+		if n >= 1 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithInstallPollInterval sets how often the state of an update is polled while it is being downloaded or installed.
+// It defaults to 30 seconds. Values lower than or equal to 0 are ignored.
+func WithInstallPollInterval(d time.Duration) FirmwareOption {
+	return func(c *firmwareConfig) {
+		if d > 0 {
+			c.pollInterval = d
+		}
+	}
+}
+
+// WithInstallProgress registers a callback notified every time the state of an update changes. The callback is never
+// called concurrently, even when several updates are installed at the same time.
+func WithInstallProgress(fn func(FirmwareUpdate)) FirmwareOption {
+	return func(c *firmwareConfig) {
+		c.onProgress = fn
+	}
+}
+
+// GetFirmwareUpdates returns the devices having a software update, whether it is still being downloaded by the
+// bridge, ready to install, being installed or failed. The updates are sorted by device name.
+func (h *Home) GetFirmwareUpdates(ctx context.Context) ([]FirmwareUpdate, error) {
+
+	updates, err := h.GetDeviceSoftwareUpdates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := h.GetDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []FirmwareUpdate
+	for _, u := range updates {
+		if u.State == nil || *u.State == NoUpdate {
+			continue
+		}
+		fu := newFirmwareUpdate(u)
+		if device, ok := devices[fu.DeviceId]; ok {
+			fu.DeviceName = deviceName(device)
+		}
+		pending = append(pending, fu)
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		if pending[i].DeviceName != pending[j].DeviceName {
+			return pending[i].DeviceName < pending[j].DeviceName
+		}
+		return pending[i].UpdateId < pending[j].UpdateId
+	})
+
+	return pending, nil
+}
+
+// InstallFirmwareUpdates installs the given updates and waits for their completion, polling their state. Updates that
+// are still being downloaded by the bridge are installed once they are ready. The results are in the same order as the
+// updates. The returned error is only set when the context is done before all updates are installed.
+//
+// Installing an update takes several minutes per device, and the bridge may refuse to install too many of them at the
+// same time: keep the concurrency low when updating many devices.
+//
+// Example:
+//
+//	updates, err := home.GetFirmwareUpdates(ctx)
+//	results, err := home.InstallFirmwareUpdates(ctx, updates,
+//		openhue.WithInstallConcurrency(5),
+//		openhue.WithInstallProgress(func(u openhue.FirmwareUpdate) {
+//			fmt.Println(u.DeviceName, u.State)
+//		}))
+func (h *Home) InstallFirmwareUpdates(ctx context.Context, updates []FirmwareUpdate, opts ...FirmwareOption) ([]FirmwareResult, error) {
+
+	cfg := &firmwareConfig{concurrency: 1, pollInterval: 30 * time.Second}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var mu sync.Mutex
+	progress := func(u FirmwareUpdate) {
+		if cfg.onProgress == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		cfg.onProgress(u)
+	}
+
+	results := make([]FirmwareResult, len(updates))
+	next := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < cfg.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = h.installFirmware(ctx, updates[i], cfg.pollInterval, progress)
+			}
+		}()
+	}
+
+	for i := range updates {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		for i := range results {
+			if results[i].UpdateId == "" {
+				results[i] = FirmwareResult{FirmwareUpdate: updates[i], Err: err}
+			}
+		}
+		return results, err
+	}
+
+	return results, nil
+}
+
+// installFirmware waits for the update to be ready, starts its installation and waits for its completion. The
+// installation fails if the update gets back to ready_to_install once it has started.
+func (h *Home) installFirmware(ctx context.Context, u FirmwareUpdate, interval time.Duration, progress func(FirmwareUpdate)) FirmwareResult {
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	installing := false
+	for {
+		current, err := h.GetDeviceSoftwareUpdateById(ctx, u.UpdateId)
+		if err != nil {
+			return FirmwareResult{FirmwareUpdate: u, Err: err}
+		}
+
+		previous := u.State
+		fu := newFirmwareUpdate(*current)
+		fu.DeviceName = u.DeviceName
+		u = fu
+		if u.State != previous {
+			progress(u)
+		}
+
+		switch u.State {
+		case ReadyToInstall:
+			if installing && previous != ReadyToInstall {
+				// the bridge went back to ready_to_install, it aborted the installation
+				return FirmwareResult{FirmwareUpdate: u, Err: firmwareError(u)}
+			}
+			if !installing {
+				installState := Install
+				body := DeviceSoftwareUpdatePut{Install: &struct {
+					InstallState *DeviceSoftwareUpdatePutInstallInstallState `json:"install_state,omitempty"`
+				}{InstallState: &installState}}
+				if err := h.UpdateDeviceSoftwareUpdate(ctx, u.UpdateId, body); err != nil {
+					return FirmwareResult{FirmwareUpdate: u, Err: err}
+				}
+				installing = true
+			}
+		case NoUpdate:
+			return FirmwareResult{FirmwareUpdate: u}
+		case InstallFailed:
+			return FirmwareResult{FirmwareUpdate: u, Err: firmwareError(u)}
+		}
+
+		select {
+		case <-ctx.Done():
+			return FirmwareResult{FirmwareUpdate: u, Err: ctx.Err()}
+		case <-ticker.C:
+		}
+	}
+}
+
+func newFirmwareUpdate(u DeviceSoftwareUpdateGet) FirmwareUpdate {
+	fu := FirmwareUpdate{}
+	if u.Id != nil {
+		fu.UpdateId = *u.Id
+	}
+	if u.Owner != nil && u.Owner.Rid != nil {
+		fu.DeviceId = *u.Owner.Rid
+	}
+	if u.State != nil {
+		fu.State = *u.State
+	}
+	if u.Problems != nil {
+		fu.Problems = *u.Problems
+	}
+	return fu
+}
+
+func firmwareError(u FirmwareUpdate) error {
+	if len(u.Problems) == 0 {
+		return fmt.Errorf("device %s: %w", u.DeviceId, ErrFirmwareInstall)
+	}
+	return fmt.Errorf("device %s: %w: %s", u.DeviceId, ErrFirmwareInstall, strings.Join(u.Problems, ", "))
+}
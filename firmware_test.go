@@ -0,0 +1,136 @@
+package openhue
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func softwareUpdateResponse(t *testing.T, id, deviceId string, state DeviceSoftwareUpdateGetState, problems ...string) *GetDeviceSoftwareUpdateResponse {
+	resp := dataResponse[*GetDeviceSoftwareUpdateResponse](t, `[{"id": "`+id+`", "owner": {"rid": "`+deviceId+`", "rtype": "device"}, "state": "`+string(state)+`"}]`)
+	if len(problems) > 0 {
+		(*resp.JSON200.Data)[0].Problems = &problems
+	}
+	return resp
+}
+
+func TestGetFirmwareUpdates(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetDeviceSoftwareUpdatesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetDeviceSoftwareUpdatesResponse](t, `[
+		{"id": "su-1", "owner": {"rid": "dev-1", "rtype": "device"}, "state": "no_update"},
+		{"id": "su-2", "owner": {"rid": "dev-2", "rtype": "device"}, "state": "ready_to_install"},
+		{"id": "su-3", "owner": {"rid": "dev-3", "rtype": "device"}, "state": "update_available", "problems": ["battery_low"]}
+	]`), nil)
+
+	m.On("GetDevicesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetDevicesResponse](t, `[
+		{"id": "dev-2", "metadata": {"name": "Kitchen"}},
+		{"id": "dev-3", "metadata": {"name": "Bedroom"}}
+	]`), nil)
+
+	updates, err := home.GetFirmwareUpdates(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []FirmwareUpdate{
+		{UpdateId: "su-3", DeviceId: "dev-3", DeviceName: "Bedroom", State: UpdateAvailable, Problems: []string{"battery_low"}},
+		{UpdateId: "su-2", DeviceId: "dev-2", DeviceName: "Kitchen", State: ReadyToInstall},
+	}, updates)
+}
+
+func TestInstallFirmwareUpdates(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-1", mock.Anything).
+		Return(softwareUpdateResponse(t, "su-1", "dev-1", ReadyToInstall), nil).Once()
+	m.On("GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-1", mock.Anything).
+		Return(softwareUpdateResponse(t, "su-1", "dev-1", Installing), nil).Once()
+	m.On("GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-1", mock.Anything).
+		Return(softwareUpdateResponse(t, "su-1", "dev-1", NoUpdate), nil).Once()
+
+	m.On("GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-2", mock.Anything).
+		Return(softwareUpdateResponse(t, "su-2", "dev-2", ReadyToInstall), nil).Once()
+	m.On("GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-2", mock.Anything).
+		Return(softwareUpdateResponse(t, "su-2", "dev-2", InstallFailed, "device_unreachable"), nil).Once()
+
+	m.On("UpdateDeviceSoftwareUpdateWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&UpdateDeviceSoftwareUpdateResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	var states []DeviceSoftwareUpdateGetState
+	results, err := home.InstallFirmwareUpdates(context.Background(), []FirmwareUpdate{
+		{UpdateId: "su-1", DeviceId: "dev-1", DeviceName: "Kitchen", State: ReadyToInstall},
+		{UpdateId: "su-2", DeviceId: "dev-2", DeviceName: "Bedroom", State: ReadyToInstall},
+	},
+		WithInstallPollInterval(time.Millisecond),
+		WithInstallProgress(func(u FirmwareUpdate) {
+			states = append(states, u.State)
+		}))
+	assert.NoError(t, err)
+
+	assert.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, NoUpdate, results[0].State)
+	assert.Equal(t, "Kitchen", results[0].DeviceName)
+
+	assert.ErrorIs(t, results[1].Err, ErrFirmwareInstall)
+	assert.ErrorContains(t, results[1].Err, "device_unreachable")
+	assert.Equal(t, []string{"device_unreachable"}, results[1].Problems)
+
+	assert.Equal(t, []DeviceSoftwareUpdateGetState{Installing, NoUpdate, InstallFailed}, states)
+
+	m.AssertNumberOfCalls(t, "UpdateDeviceSoftwareUpdateWithResponse", 2)
+	m.AssertCalled(t, "UpdateDeviceSoftwareUpdateWithResponse", mock.Anything, "su-1", mock.MatchedBy(func(body DeviceSoftwareUpdatePut) bool {
+		return *body.Install.InstallState == Install
+	}), mock.Anything)
+}
+
+func TestInstallFirmwareUpdates_Aborted(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-1", mock.Anything).
+		Return(softwareUpdateResponse(t, "su-1", "dev-1", ReadyToInstall), nil).Twice()
+	m.On("GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-1", mock.Anything).
+		Return(softwareUpdateResponse(t, "su-1", "dev-1", Installing), nil).Once()
+	m.On("GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-1", mock.Anything).
+		Return(softwareUpdateResponse(t, "su-1", "dev-1", ReadyToInstall), nil)
+
+	m.On("UpdateDeviceSoftwareUpdateWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&UpdateDeviceSoftwareUpdateResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	results, err := home.InstallFirmwareUpdates(ctx, []FirmwareUpdate{
+		{UpdateId: "su-1", DeviceId: "dev-1", State: ReadyToInstall},
+	}, WithInstallPollInterval(time.Millisecond))
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, results[0].Err, ErrFirmwareInstall)
+	assert.Equal(t, ReadyToInstall, results[0].State)
+	m.AssertNumberOfCalls(t, "UpdateDeviceSoftwareUpdateWithResponse", 1)
+}
+
+func TestInstallFirmwareUpdates_ContextCanceled(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-1", mock.Anything).
+		Return(softwareUpdateResponse(t, "su-1", "dev-1", UpdateAvailable), nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	results, err := home.InstallFirmwareUpdates(ctx, []FirmwareUpdate{
+		{UpdateId: "su-1", DeviceId: "dev-1"},
+		{UpdateId: "su-2", DeviceId: "dev-2"},
+	}, WithInstallPollInterval(time.Millisecond))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, results[0].Err, context.DeadlineExceeded)
+	assert.ErrorIs(t, results[1].Err, context.DeadlineExceeded)
+
+	m.AssertNotCalled(t, "GetDeviceSoftwareUpdateWithResponse", mock.Anything, "su-2", mock.Anything)
+	m.AssertNotCalled(t, "UpdateDeviceSoftwareUpdateWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,98 @@
+package openhue
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func geofenceClientsResponse(t *testing.T, clients string) *GetGeofenceClientsResponse {
+	return dataResponse[*GetGeofenceClientsResponse](t, ``+clients+``)
+}
+
+func TestSetPresence_Existing(t *testing.T) {
+	home, m := NewTestHome()
+	m.On("GetGeofenceClientsWithResponse", mock.Anything, mock.Anything).Return(geofenceClientsResponse(t, `[
+		{"id": "gc-1", "name": "alice-phone", "is_at_home": false},
+		{"id": "gc-2", "name": "bob-badge", "is_at_home": true}
+	]`), nil)
+	m.On("UpdateGeofenceClientWithResponse", mock.Anything, "gc-1", mock.Anything, mock.Anything).Return(&UpdateGeofenceClientResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	id, err := home.SetPresence(context.Background(), "alice-phone", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "gc-1", id)
+
+	id, err = home.SetPresence(context.Background(), "bob-badge", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "gc-2", id)
+
+	m.AssertNumberOfCalls(t, "UpdateGeofenceClientWithResponse", 1)
+	m.AssertCalled(t, "UpdateGeofenceClientWithResponse", mock.Anything, "gc-1", mock.MatchedBy(func(body GeofenceClientPut) bool {
+		return *body.IsAtHome && body.Name == nil
+	}), mock.Anything)
+}
+
+func TestSetPresence_Create(t *testing.T) {
+	home, m := NewTestHome()
+	m.On("GetGeofenceClientsWithResponse", mock.Anything, mock.Anything).Return(geofenceClientsResponse(t, `[]`), nil)
+
+	withTestServer(t, home, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/clip/v2/resource/geofence_client", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"type": "geofence_client", "name": "carol-phone", "is_at_home": false}`, string(body))
+		_, _ = w.Write([]byte(`{"data": [{"rid": "gc-3", "rtype": "geofence_client"}], "errors": []}`))
+	})
+
+	id, err := home.SetPresence(context.Background(), "carol-phone", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "gc-3", id)
+}
+
+func TestDeleteGeofenceClient(t *testing.T) {
+	home, _ := NewTestHome()
+
+	withTestServer(t, home, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/clip/v2/resource/geofence_client/gc-1", r.URL.Path)
+		assert.Equal(t, http.NoBody, r.Body)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"data": [], "errors": [{"description": "Not found"}]}`))
+	})
+
+	err := home.DeleteGeofenceClient(context.Background(), "gc-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "openhue api error (404): Not found")
+}
+
+func TestSetGeolocation(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetGeolocationsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetGeolocationsResponse](t, `[{"id": "geo-1", "is_configured": false}]`), nil)
+
+	var sent string
+	m.On("UpdateGeolocationWithBodyWithResponse", mock.Anything, "geo-1", "application/json", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			data, _ := io.ReadAll(args.Get(3).(io.Reader))
+			sent = string(data)
+		}).
+		Return(&UpdateGeolocationResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	configured, err := home.IsGeolocationConfigured(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, configured)
+
+	err = home.SetGeolocation(context.Background(), 48.8566, 2.3522)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type": "geolocation", "latitude": 48.8566, "longitude": 2.3522}`, sent)
+
+	err = home.SetGeolocation(context.Background(), 91, 0)
+	assert.ErrorContains(t, err, "illegal arguments")
+}
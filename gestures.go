@@ -0,0 +1,428 @@
+package openhue
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// BUTTON GESTURES
+//--------------------------------------------------------------------------------------------------------------------//
+
+// ButtonEvent is an event reported by a button, such as a key of a Hue dimmer switch.
+type ButtonEvent struct {
+	ButtonId string
+	// ControlId is the control identifier of the button, unique within its device. It is 0 if unknown.
+	ControlId int
+	// DeviceId is the ID of the device owning the button, empty if unknown. Buttons of the same device pressed together
+	// are recognized as a GestureCombo.
+	DeviceId string
+	Event    ButtonGetButtonButtonReportEvent
+	Updated  time.Time
+}
+
+// LastReport returns the last event reported by the button, false if it has never been pressed.
+func (b *ButtonGet) LastReport() (ButtonEvent, bool) {
+	if b.Button == nil || b.Button.ButtonReport == nil || b.Button.ButtonReport.Event == nil {
+		return ButtonEvent{}, false
+	}
+	event := ButtonEvent{Event: *b.Button.ButtonReport.Event}
+	if b.Id != nil {
+		event.ButtonId = *b.Id
+	}
+	if b.Metadata != nil && b.Metadata.ControlId != nil {
+		event.ControlId = *b.Metadata.ControlId
+	}
+	if b.Owner != nil && b.Owner.Rid != nil {
+		event.DeviceId = *b.Owner.Rid
+	}
+	if b.Button.ButtonReport.Updated != nil {
+		event.Updated = *b.Button.ButtonReport.Updated
+	}
+	return event, true
+}
+
+// GestureKind is the kind of gesture recognized by a GestureRecognizer.
+type GestureKind int
+
+const (
+	// GestureShortPress is a single short press, emitted once the double press window has elapsed.
+	GestureShortPress GestureKind = iota
+	// GestureDoublePress is two short presses of the same button within the double press window.
+	GestureDoublePress
+	// GestureLongPress is emitted once, when a button has been held long enough.
+	GestureLongPress
+	// GestureHoldRepeat is emitted for each repeat event while a button is held.
+	GestureHoldRepeat
+	// GestureLongRelease is the release of a held button.
+	GestureLongRelease
+	// GestureCombo is several buttons of the same device pressed together. It is emitted each time a button joins the
+	// combination, and the buttons involved emit no other gesture until they are released.
+	GestureCombo
+)
+
+// Gesture is a high-level gesture recognized from button events.
+type Gesture struct {
+	Kind      GestureKind
+	ButtonId  string
+	ControlId int
+	DeviceId  string
+	// Controls is the control identifiers of the buttons pressed together, in increasing order. It is only set for a
+	// GestureCombo.
+	Controls []int
+	// Repeats is the number of repeat events received since the button is held, for GestureHoldRepeat and
+	// GestureLongRelease.
+	Repeats int
+	// Duration is how long the button was held, for GestureLongRelease.
+	Duration time.Duration
+	// Time is the time of the button event that completed the gesture.
+	Time time.Time
+}
+
+type gestureConfig struct {
+	doublePressWindow time.Duration
+	longPressDuration time.Duration
+	comboWindow       time.Duration
+	interval          time.Duration
+}
+
+// GestureOption is a functional option for configuring a GestureRecognizer.
+type GestureOption func(*gestureConfig)
+
+// WithDoublePressWindow sets the maximum delay between the release of a short press and the next press of the same
+// button for both to be recognized as a GestureDoublePress. It defaults to 400 milliseconds. When set to 0, double
+// presses are not recognized and short presses are emitted as soon as the button is released. Negative values are
+// ignored.
+func WithDoublePressWindow(d time.Duration) GestureOption {
+	return func(c *gestureConfig) {
+		if d >= 0 {
+			c.doublePressWindow = d
+		}
+	}
+}
+
+// WithLongPressDuration sets how long a button must be held to be recognized as a GestureLongPress, when the bridge
+// does not report a long_press or a repeat event earlier. It defaults to 800 milliseconds. Values lower than or equal
+// to 0 are ignored.
+func WithLongPressDuration(d time.Duration) GestureOption {
+	return func(c *gestureConfig) {
+		if d > 0 {
+			c.longPressDuration = d
+		}
+	}
+}
+
+// WithComboWindow sets the maximum delay between the presses of buttons of the same device for them to be recognized
+// as a GestureCombo. It defaults to 300 milliseconds. When set to 0, combinations are not recognized. Negative values
+// are ignored.
+func WithComboWindow(d time.Duration) GestureOption {
+	return func(c *gestureConfig) {
+		if d >= 0 {
+			c.comboWindow = d
+		}
+	}
+}
+
+// WithGestureInterval sets how often Run polls the buttons. It defaults to 100 milliseconds. Values lower than or
+// equal to 0 are ignored.
+func WithGestureInterval(d time.Duration) GestureOption {
+	return func(c *gestureConfig) {
+		if d > 0 {
+			c.interval = d
+		}
+	}
+}
+
+// buttonState is the state of a single button tracked by a GestureRecognizer.
+type buttonState struct {
+	controlId int
+	deviceId  string
+
+	pressed   bool
+	pressedAt time.Time
+	// second is true when the current press may complete a double press.
+	second  bool
+	held    bool
+	repeats int
+	combo   bool
+
+	// short is the short press waiting for the double press window to elapse, nil if none.
+	short *Gesture
+}
+
+// GestureRecognizer turns button events into high-level gestures: short, double and long presses, hold repeats and
+// combinations of buttons. It only relies on the timestamps of the events, so that it can be fed from the event
+// stream of the bridge, from polling, or from synthetic events in tests.
+//
+// Some gestures can only be recognized once a delay has elapsed without any other event, such as a short press that
+// did not turn into a double press: call Tick regularly to emit them.
+type GestureRecognizer struct {
+	cfg gestureConfig
+
+	mu      sync.Mutex
+	buttons map[string]*buttonState
+}
+
+// NewGestureRecognizer returns a recognizer with the given time windows.
+//
+// Example:
+//
+//	recognizer := openhue.NewGestureRecognizer(openhue.WithDoublePressWindow(300 * time.Millisecond))
+//	err := recognizer.Run(ctx, home, func(g openhue.Gesture) {
+//		if g.Kind == openhue.GestureDoublePress && g.ControlId == 1 {
+//			// turn all the lights on
+//		}
+//	})
+func NewGestureRecognizer(opts ...GestureOption) *GestureRecognizer {
+
+	cfg := gestureConfig{
+		doublePressWindow: 400 * time.Millisecond,
+		longPressDuration: 800 * time.Millisecond,
+		comboWindow:       300 * time.Millisecond,
+		interval:          100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &GestureRecognizer{cfg: cfg, buttons: make(map[string]*buttonState)}
+}
+
+// Handle processes a button event and returns the gestures it completes, including the ones that became due since the
+// previous call. Events of a given button must be handled in chronological order.
+func (r *GestureRecognizer) Handle(event ButtonEvent) []Gesture {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	gestures := r.tick(event.Updated)
+
+	b, ok := r.buttons[event.ButtonId]
+	if !ok {
+		b = &buttonState{}
+		r.buttons[event.ButtonId] = b
+	}
+	// events from the event stream only carry the ID of the button
+	if event.ControlId != 0 {
+		b.controlId = event.ControlId
+	}
+	if event.DeviceId != "" {
+		b.deviceId = event.DeviceId
+	}
+
+	gesture := func(kind GestureKind) Gesture {
+		return Gesture{Kind: kind, ButtonId: event.ButtonId, ControlId: b.controlId, DeviceId: b.deviceId, Time: event.Updated}
+	}
+
+	// polling may miss the press of the button
+	if !b.pressed && event.Event != ButtonGetButtonButtonReportEventInitialPress {
+		r.press(b, event.Updated)
+	}
+
+	switch event.Event {
+
+	case ButtonGetButtonButtonReportEventInitialPress:
+		r.press(b, event.Updated)
+		if combo := r.combo(event.ButtonId, b, event.Updated); combo != nil {
+			g := gesture(GestureCombo)
+			g.Controls = combo
+			gestures = append(gestures, g)
+		}
+
+	case ButtonGetButtonButtonReportEventLongPress, ButtonGetButtonButtonReportEventRepeat:
+		if b.combo {
+			break
+		}
+		if !b.held {
+			gestures = append(gestures, r.hold(b, gesture(GestureLongPress))...)
+		}
+		if event.Event == ButtonGetButtonButtonReportEventRepeat {
+			b.repeats++
+			g := gesture(GestureHoldRepeat)
+			g.Repeats = b.repeats
+			gestures = append(gestures, g)
+		}
+
+	case ButtonGetButtonButtonReportEventShortRelease, ButtonGetButtonButtonReportEventLongRelease,
+		ButtonGetButtonButtonReportEventDoubleShortRelease:
+		b.pressed = false
+		switch {
+		case b.combo:
+		case b.held || event.Event == ButtonGetButtonButtonReportEventLongRelease:
+			if !b.held {
+				gestures = append(gestures, r.hold(b, gesture(GestureLongPress))...)
+			}
+			g := gesture(GestureLongRelease)
+			g.Repeats = b.repeats
+			g.Duration = event.Updated.Sub(b.pressedAt)
+			gestures = append(gestures, g)
+		case b.second || event.Event == ButtonGetButtonButtonReportEventDoubleShortRelease:
+			b.short = nil
+			gestures = append(gestures, gesture(GestureDoublePress))
+		case r.cfg.doublePressWindow == 0:
+			gestures = append(gestures, gesture(GestureShortPress))
+		default:
+			g := gesture(GestureShortPress)
+			b.short = &g
+		}
+	}
+
+	return gestures
+}
+
+// Tick returns the gestures that became due at the given time: the short presses which double press window has
+// elapsed, and the long presses of buttons held for long enough.
+func (r *GestureRecognizer) Tick(now time.Time) []Gesture {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.tick(now)
+}
+
+// Run polls the buttons of the bridge, recognizes the gestures of their new events and calls handle for each of them,
+// until the context is done. Polling only sees the last event of each button, so fast sequences such as double
+// presses may be missed: when the events are available from the event stream, call Handle and Tick directly instead.
+func (r *GestureRecognizer) Run(ctx context.Context, home *Home, handle func(Gesture)) error {
+
+	buttons, err := home.GetButtons(ctx)
+	if err != nil {
+		return err
+	}
+	last := make(map[string]time.Time)
+	for id, button := range buttons {
+		if event, ok := button.LastReport(); ok {
+			last[id] = event.Updated
+		}
+	}
+
+	// offset estimates the difference between the clock of the bridge and the local clock, the timestamps of the
+	// events being given by the bridge
+	var offset time.Duration
+	synced := false
+
+	ticker := time.NewTicker(r.cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		buttons, err := home.GetButtons(ctx)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+
+		var events []ButtonEvent
+		for id, button := range buttons {
+			if event, ok := button.LastReport(); ok && event.Updated.After(last[id]) {
+				events = append(events, event)
+				last[id] = event.Updated
+			}
+		}
+		sort.Slice(events, func(i, j int) bool {
+			return events[i].Updated.Before(events[j].Updated)
+		})
+
+		for _, event := range events {
+			// the event happened before it was polled
+			if d := event.Updated.Sub(now); !synced || d > offset {
+				offset, synced = d, true
+			}
+			for _, g := range r.Handle(event) {
+				handle(g)
+			}
+		}
+		for _, g := range r.Tick(now.Add(offset)) {
+			handle(g)
+		}
+	}
+}
+
+func (r *GestureRecognizer) tick(now time.Time) []Gesture {
+
+	var gestures []Gesture
+
+	for _, id := range sortedIds(r.buttons) {
+		b := r.buttons[id]
+		if b.short != nil && !b.pressed && now.Sub(b.short.Time) > r.cfg.doublePressWindow {
+			gestures = append(gestures, *b.short)
+			b.short = nil
+		}
+		if b.pressed && !b.held && !b.combo && now.Sub(b.pressedAt) >= r.cfg.longPressDuration {
+			gestures = append(gestures, r.hold(b, Gesture{
+				Kind:      GestureLongPress,
+				ButtonId:  id,
+				ControlId: b.controlId,
+				DeviceId:  b.deviceId,
+				Time:      b.pressedAt.Add(r.cfg.longPressDuration),
+			})...)
+		}
+	}
+
+	sort.SliceStable(gestures, func(i, j int) bool {
+		return gestures[i].Time.Before(gestures[j].Time)
+	})
+
+	return gestures
+}
+
+// press starts a new press of the button.
+func (r *GestureRecognizer) press(b *buttonState, at time.Time) {
+	b.second = b.short != nil
+	b.pressed, b.pressedAt = true, at
+	b.held, b.repeats, b.combo = false, 0, false
+}
+
+// hold marks the button as held, and returns the long press preceded by the short press still pending, if any.
+func (r *GestureRecognizer) hold(b *buttonState, longPress Gesture) []Gesture {
+	var gestures []Gesture
+	if b.short != nil {
+		gestures = append(gestures, *b.short)
+		b.short = nil
+	}
+	b.held = true
+	return append(gestures, longPress)
+}
+
+// combo returns the control identifiers of the buttons pressed together with the given one, nil if none. The buttons
+// involved are marked as part of a combination.
+func (r *GestureRecognizer) combo(buttonId string, b *buttonState, at time.Time) []int {
+
+	if r.cfg.comboWindow == 0 || b.deviceId == "" {
+		return nil
+	}
+
+	var peers []*buttonState
+	for id, other := range r.buttons {
+		if id == buttonId || other.deviceId != b.deviceId || !other.pressed {
+			continue
+		}
+		// a button held before the combination started has already emitted its own gestures
+		if !other.combo && (other.held || at.Sub(other.pressedAt) > r.cfg.comboWindow) {
+			continue
+		}
+		peers = append(peers, other)
+	}
+	if len(peers) == 0 {
+		return nil
+	}
+
+	controls := []int{b.controlId}
+	for _, p := range append(peers, b) {
+		p.combo, p.short, p.second = true, nil, false
+		if p != b {
+			controls = append(controls, p.controlId)
+		}
+	}
+	slices.Sort(controls)
+
+	return controls
+}
@@ -0,0 +1,159 @@
+package openhue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var gestureStart = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func buttonEvent(buttonId string, controlId int, event ButtonGetButtonButtonReportEvent, ms int) ButtonEvent {
+	return ButtonEvent{
+		ButtonId:  buttonId,
+		ControlId: controlId,
+		DeviceId:  "device-1",
+		Event:     event,
+		Updated:   gestureStart.Add(time.Duration(ms) * time.Millisecond),
+	}
+}
+
+func gestureKinds(gestures []Gesture) []GestureKind {
+	kinds := make([]GestureKind, 0, len(gestures))
+	for _, g := range gestures {
+		kinds = append(kinds, g.Kind)
+	}
+	return kinds
+}
+
+func TestButtonGet_LastReport(t *testing.T) {
+	button := fromJSON[ButtonGet](t, `{
+		"id": "btn-1", "metadata": {"control_id": 2}, "owner": {"rid": "device-1", "rtype": "device"},
+		"button": {"button_report": {"event": "short_release", "updated": "2024-01-01T10:00:00Z"}}
+	}`)
+
+	event, ok := button.LastReport()
+	assert.True(t, ok)
+	assert.Equal(t, buttonEvent("btn-1", 2, ButtonGetButtonButtonReportEventShortRelease, 0), event)
+
+	_, ok = (&ButtonGet{}).LastReport()
+	assert.False(t, ok)
+}
+
+func TestGestureRecognizer_ShortAndDoublePress(t *testing.T) {
+	r := NewGestureRecognizer()
+
+	assert.Empty(t, r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventInitialPress, 0)))
+	assert.Empty(t, r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventShortRelease, 100)))
+	assert.Empty(t, r.Tick(gestureStart.Add(400*time.Millisecond)))
+
+	gestures := r.Tick(gestureStart.Add(600 * time.Millisecond))
+	assert.Equal(t, []Gesture{{
+		Kind:      GestureShortPress,
+		ButtonId:  "btn-1",
+		ControlId: 1,
+		DeviceId:  "device-1",
+		Time:      gestureStart.Add(100 * time.Millisecond),
+	}}, gestures)
+
+	assert.Empty(t, r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventInitialPress, 1000)))
+	assert.Empty(t, r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventShortRelease, 1100)))
+	assert.Empty(t, r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventInitialPress, 1300)))
+	gestures = r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventShortRelease, 1400))
+	assert.Equal(t, []GestureKind{GestureDoublePress}, gestureKinds(gestures))
+	assert.Empty(t, r.Tick(gestureStart.Add(5*time.Second)))
+}
+
+func TestGestureRecognizer_HoldRepeat(t *testing.T) {
+	r := NewGestureRecognizer()
+
+	// a short press followed by a hold within the double press window
+	r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventInitialPress, 0))
+	r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventShortRelease, 100))
+	assert.Empty(t, r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventInitialPress, 300)))
+
+	gestures := r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventRepeat, 1100))
+	assert.Equal(t, []GestureKind{GestureShortPress, GestureLongPress, GestureHoldRepeat}, gestureKinds(gestures))
+	assert.Equal(t, gestureStart.Add(1100*time.Millisecond), gestures[1].Time)
+
+	gestures = r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventRepeat, 1900))
+	assert.Equal(t, []GestureKind{GestureHoldRepeat}, gestureKinds(gestures))
+	assert.Equal(t, 2, gestures[0].Repeats)
+
+	gestures = r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventLongRelease, 2300))
+	assert.Equal(t, []GestureKind{GestureLongRelease}, gestureKinds(gestures))
+	assert.Equal(t, 2, gestures[0].Repeats)
+	assert.Equal(t, 2*time.Second, gestures[0].Duration)
+}
+
+func TestGestureRecognizer_LongPressDuration(t *testing.T) {
+	r := NewGestureRecognizer(WithLongPressDuration(500 * time.Millisecond))
+
+	r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventInitialPress, 0))
+	assert.Empty(t, r.Tick(gestureStart.Add(400*time.Millisecond)))
+
+	gestures := r.Tick(gestureStart.Add(600 * time.Millisecond))
+	assert.Equal(t, []GestureKind{GestureLongPress}, gestureKinds(gestures))
+	assert.Equal(t, gestureStart.Add(500*time.Millisecond), gestures[0].Time)
+
+	// the bridge may still report a short release for a press held that long
+	gestures = r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventShortRelease, 700))
+	assert.Equal(t, []GestureKind{GestureLongRelease}, gestureKinds(gestures))
+}
+
+func TestGestureRecognizer_Combo(t *testing.T) {
+	r := NewGestureRecognizer()
+
+	r.Handle(buttonEvent("btn-4", 4, ButtonGetButtonButtonReportEventInitialPress, 0))
+	gestures := r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventInitialPress, 150))
+	assert.Equal(t, []GestureKind{GestureCombo}, gestureKinds(gestures))
+	assert.Equal(t, []int{1, 4}, gestures[0].Controls)
+
+	assert.Empty(t, r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventRepeat, 900)))
+	assert.Empty(t, r.Handle(buttonEvent("btn-4", 4, ButtonGetButtonButtonReportEventLongRelease, 1000)))
+	assert.Empty(t, r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventLongRelease, 1050)))
+	assert.Empty(t, r.Tick(gestureStart.Add(5*time.Second)))
+
+	// presses too far apart are separate gestures
+	r.Handle(buttonEvent("btn-4", 4, ButtonGetButtonButtonReportEventInitialPress, 6000))
+	assert.Empty(t, r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventInitialPress, 6500)))
+}
+
+func TestGestureRecognizer_MissedPress(t *testing.T) {
+	r := NewGestureRecognizer(WithDoublePressWindow(0))
+
+	gestures := r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventShortRelease, 0))
+	assert.Equal(t, []GestureKind{GestureShortPress}, gestureKinds(gestures))
+
+	gestures = r.Handle(buttonEvent("btn-1", 1, ButtonGetButtonButtonReportEventDoubleShortRelease, 2000))
+	assert.Equal(t, []GestureKind{GestureDoublePress}, gestureKinds(gestures))
+}
+
+func TestGestureRecognizer_Run(t *testing.T) {
+	home, m := NewTestHome()
+
+	buttons := func(event string, updated time.Time) *GetButtonsResponse {
+		return dataResponse[*GetButtonsResponse](t, `[{"id": "btn-1", "metadata": {"control_id": 1}, "button": {"button_report": {
+			"event": "`+event+`", "updated": "`+updated.Format(time.RFC3339Nano)+`"
+		}}}]`)
+	}
+	m.On("GetButtonsWithResponse", mock.Anything, mock.Anything).Return(buttons("short_release", gestureStart), nil).Twice()
+	m.On("GetButtonsWithResponse", mock.Anything, mock.Anything).Return(buttons("short_release", gestureStart.Add(time.Minute)), nil)
+
+	r := NewGestureRecognizer(WithDoublePressWindow(0), WithGestureInterval(time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	var gestures []Gesture
+	err := r.Run(ctx, home, func(g Gesture) {
+		gestures = append(gestures, g)
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.Equal(t, []GestureKind{GestureShortPress}, gestureKinds(gestures))
+	assert.Equal(t, 1, gestures[0].ControlId)
+}
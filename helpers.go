@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"gopkg.in/yaml.v3"
 	"os"
+	"sort"
 )
 
 // Toggleable defines resources that have an On field and can therefore be switched to on or off, mainly lights.
@@ -54,3 +55,26 @@ func CheckErr(msg error) {
 		os.Exit(1)
 	}
 }
+
+// sortedKeys returns the keys of all the maps, without duplicates, sorted by the less function.
+func sortedKeys[K comparable, V any](less func(a, b K) bool, maps ...map[K]V) []K {
+	seen := make(map[K]bool)
+	var keys []K
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+	return keys
+}
+
+// sortedIds returns the keys of all the maps of resources, without duplicates, sorted.
+func sortedIds[T any](resources ...map[string]T) []string {
+	return sortedKeys(func(a, b string) bool { return a < b }, resources...)
+}
@@ -0,0 +1,38 @@
+package openhue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// fromJSON decodes a JSON fixture into a generated type, which is easier to read than nested anonymous structs.
+// Generated responses have no JSON tags, their fixtures use the field names, e.g. `{"JSON200": {"data": []}}`.
+func fromJSON[T any](t *testing.T, data string) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+	return v
+}
+
+// dataResponse decodes a successful response of the bridge holding the given JSON data, e.g.
+// dataResponse[*GetLightsResponse](t, `[{"id": "light-1", "metadata": {"name": "Ceiling"}}]`).
+func dataResponse[T any](t *testing.T, data string) T {
+	t.Helper()
+	return fromJSON[T](t, `{"HTTPResponse": {"StatusCode": 200}, "JSON200": {"data": `+data+`}}`)
+}
+
+// withTestServer routes the requests sent outside of the generated client, to the operations missing from the API
+// specification, to a test server running the given handler.
+func withTestServer(t *testing.T, home *Home, handler http.HandlerFunc) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	home.client = &Client{Server: server.URL + "/", Client: server.Client()}
+}
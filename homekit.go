@@ -0,0 +1,94 @@
+package openhue
+
+import (
+	"context"
+	"fmt"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// HOMEKIT
+//--------------------------------------------------------------------------------------------------------------------//
+
+// HomekitStatus is the state of the HomeKit integration of the bridge.
+type HomekitStatus struct {
+	// Id is the ID of the homekit resource.
+	Id     string
+	Status HomekitGetStatus
+	// Action is the HomeKit action currently running on the bridge, empty if none.
+	Action HomekitGetAction
+}
+
+// IsPaired returns true when the bridge is part of an Apple home.
+func (s *HomekitStatus) IsPaired() bool {
+	return s.Status == HomekitGetStatusPaired
+}
+
+// HomekitResetResult is the outcome of ResetHomekit.
+type HomekitResetResult struct {
+	Id string
+	// PreviousStatus is the pairing status of the bridge before the reset.
+	PreviousStatus HomekitGetStatus
+	// Reset is false when the bridge was not paired, in which case no reset has been requested.
+	Reset bool
+}
+
+// GetHomekitStatus returns the HomeKit pairing status of the bridge.
+// It returns an error wrapping ErrNotFound if the bridge does not support HomeKit.
+func (h *Home) GetHomekitStatus(ctx context.Context) (*HomekitStatus, error) {
+
+	homekits, err := h.GetHomekits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := sortedIds(homekits)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("homekit: %w", ErrNotFound)
+	}
+
+	homekit := homekits[ids[0]]
+	status := &HomekitStatus{Id: *homekit.Id}
+	if homekit.Status != nil {
+		status.Status = *homekit.Status
+	}
+	if homekit.Action != nil {
+		status.Action = *homekit.Action
+	}
+
+	return status, nil
+}
+
+// ResetHomekit removes the bridge from the Apple home it is paired with, so that it can be added to another one.
+// As it cannot be undone, confirm must be true, otherwise an error wrapping ErrNotConfirmed is returned and nothing
+// is done. No reset is requested if the bridge is not paired.
+//
+// Example:
+//
+//	result, err := home.ResetHomekit(ctx, true)
+//	if err == nil && result.Reset {
+//		fmt.Println("bridge removed from the Apple home")
+//	}
+func (h *Home) ResetHomekit(ctx context.Context, confirm bool) (*HomekitResetResult, error) {
+
+	if !confirm {
+		return nil, fmt.Errorf("homekit reset: %w", ErrNotConfirmed)
+	}
+
+	status, err := h.GetHomekitStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HomekitResetResult{Id: status.Id, PreviousStatus: status.Status}
+	if status.Status == HomekitGetStatusUnpaired {
+		return result, nil
+	}
+
+	reset := HomekitReset
+	if err := h.UpdateHomekit(ctx, status.Id, HomekitPut{Action: &reset}); err != nil {
+		return nil, err
+	}
+	result.Reset = true
+
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package openhue
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockHomekit(t *testing.T, m *ClientWithResponsesMock, status HomekitGetStatus) {
+	m.On("GetHomekitsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetHomekitsResponse](t, `[{"id": "homekit-1", "status": "`+string(status)+`"}]`), nil)
+}
+
+func TestGetHomekitStatus(t *testing.T) {
+	home, m := NewTestHome()
+	mockHomekit(t, m, HomekitGetStatusPaired)
+
+	status, err := home.GetHomekitStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "homekit-1", status.Id)
+	assert.True(t, status.IsPaired())
+	assert.Empty(t, status.Action)
+}
+
+func TestResetHomekit(t *testing.T) {
+	home, m := NewTestHome()
+	mockHomekit(t, m, HomekitGetStatusPaired)
+	m.On("UpdateHomekitWithResponse", mock.Anything, "homekit-1", mock.Anything, mock.Anything).Return(&UpdateHomekitResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	result, err := home.ResetHomekit(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Equal(t, &HomekitResetResult{Id: "homekit-1", PreviousStatus: HomekitGetStatusPaired, Reset: true}, result)
+
+	m.AssertCalled(t, "UpdateHomekitWithResponse", mock.Anything, "homekit-1", mock.MatchedBy(func(body HomekitPut) bool {
+		return *body.Action == HomekitReset
+	}), mock.Anything)
+}
+
+func TestResetHomekit_NotConfirmed(t *testing.T) {
+	home, m := NewTestHome()
+
+	_, err := home.ResetHomekit(context.Background(), false)
+	assert.ErrorIs(t, err, ErrNotConfirmed)
+
+	m.AssertNotCalled(t, "GetHomekitsWithResponse", mock.Anything, mock.Anything)
+}
+
+func TestResetHomekit_Unpaired(t *testing.T) {
+	home, m := NewTestHome()
+	mockHomekit(t, m, HomekitGetStatusUnpaired)
+
+	result, err := home.ResetHomekit(context.Background(), true)
+	assert.NoError(t, err)
+	assert.False(t, result.Reset)
+
+	m.AssertNotCalled(t, "UpdateHomekitWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,216 @@
+package openhue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// NAME LOOKUP
+//--------------------------------------------------------------------------------------------------------------------//
+
+type findConfig struct {
+	fuzzy bool
+}
+
+// FindOption is a functional option for configuring the Find* lookup functions.
+type FindOption func(*findConfig)
+
+// WithFuzzyMatch enables fuzzy matching when no resource has exactly the searched name (case-insensitively).
+// A resource then matches when its name contains all the words of the searched name, or when it is only a few typos
+// away from it. The closest matches win.
+func WithFuzzyMatch() FindOption {
+	return func(c *findConfig) {
+		c.fuzzy = true
+	}
+}
+
+// FindLight returns the light of the given name. Names are compared case-insensitively.
+// It returns an error wrapping ErrNotFound when no light matches, and an *AmbiguousNameError when several lights match.
+// A blank name matches nothing, it returns an error wrapping ErrEmptyName.
+//
+// Example:
+//
+//	light, err := home.FindLight(ctx, "kitchen ceiling", openhue.WithFuzzyMatch())
+func (h *Home) FindLight(ctx context.Context, name string, opts ...FindOption) (*LightGet, error) {
+	lights, err := h.GetLights(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findByName(lights, ResourceIdentifierRtypeLight, name, func(l LightGet) *string {
+		if l.Metadata == nil {
+			return nil
+		}
+		return l.Metadata.Name
+	}, opts)
+}
+
+// FindRoom returns the room of the given name. See FindLight for the matching rules.
+func (h *Home) FindRoom(ctx context.Context, name string, opts ...FindOption) (*RoomGet, error) {
+	rooms, err := h.GetRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findByName(rooms, ResourceIdentifierRtypeRoom, name, groupName, opts)
+}
+
+// FindZone returns the zone of the given name. See FindLight for the matching rules.
+func (h *Home) FindZone(ctx context.Context, name string, opts ...FindOption) (*RoomGet, error) {
+	zones, err := h.GetZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findByName(zones, ResourceIdentifierRtypeZone, name, groupName, opts)
+}
+
+// FindScene returns the scene of the given name within the room or zone of the given ID. Scenes are scoped by group
+// because the same scene names are usually found in several rooms. See FindLight for the matching rules.
+//
+// Example:
+//
+//	room, err := home.FindRoom(ctx, "living room")
+//	scene, err := home.FindScene(ctx, *room.Id, "relax")
+func (h *Home) FindScene(ctx context.Context, groupId string, name string, opts ...FindOption) (*SceneGet, error) {
+	scenes, err := h.GetScenes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inGroup := make(map[string]SceneGet)
+	for id, scene := range scenes {
+		if scene.Group != nil && scene.Group.Rid != nil && *scene.Group.Rid == groupId {
+			inGroup[id] = scene
+		}
+	}
+
+	return findByName(inGroup, ResourceIdentifierRtypeScene, name, func(s SceneGet) *string {
+		if s.Metadata == nil {
+			return nil
+		}
+		return s.Metadata.Name
+	}, opts)
+}
+
+func groupName(g RoomGet) *string {
+	if g.Metadata == nil {
+		return nil
+	}
+	return g.Metadata.Name
+}
+
+// findByName looks up a resource by name, exact (case-insensitive) matches taking precedence over fuzzy ones.
+func findByName[T any](resources map[string]T, rtype ResourceIdentifierRtype, name string, nameOf func(T) *string, opts []FindOption) (*T, error) {
+
+	cfg := &findConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	query := normalizeName(name)
+	if query == "" {
+		return nil, fmt.Errorf("no %s name given: %w", rtype, ErrEmptyName)
+	}
+
+	var exact []NamedResource
+	var fuzzy []NamedResource
+	best := -1
+
+	for id, r := range resources {
+		n := nameOf(r)
+		if n == nil {
+			continue
+		}
+		candidate := NamedResource{Id: id, Name: *n}
+		normalized := normalizeName(*n)
+
+		if normalized == query {
+			exact = append(exact, candidate)
+			continue
+		}
+
+		if !cfg.fuzzy {
+			continue
+		}
+		if score, ok := fuzzyScore(query, normalized); ok {
+			switch {
+			case best < 0 || score < best:
+				best = score
+				fuzzy = []NamedResource{candidate}
+			case score == best:
+				fuzzy = append(fuzzy, candidate)
+			}
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = fuzzy
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no %s named \"%s\": %w", rtype, name, ErrNotFound)
+	case 1:
+		r := resources[matches[0].Id]
+		return &r, nil
+	default:
+		sort.Slice(matches, func(i, j int) bool {
+			return matches[i].Name < matches[j].Name || (matches[i].Name == matches[j].Name && matches[i].Id < matches[j].Id)
+		})
+		return nil, &AmbiguousNameError{Rtype: rtype, Name: name, Candidates: matches}
+	}
+}
+
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// fuzzyScore returns how far a name is from the query, the lower the better. Names containing all the words of the
+// query score 0, otherwise the score is the edit distance, which must not exceed a quarter of the query length.
+func fuzzyScore(query, name string) (int, bool) {
+
+	containsAll := true
+	for _, word := range strings.Fields(query) {
+		if !strings.Contains(name, word) {
+			containsAll = false
+			break
+		}
+	}
+	if containsAll {
+		return 0, true
+	}
+
+	maxDistance := len([]rune(query)) / 4
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	distance := levenshtein(query, name)
+	return distance, distance <= maxDistance
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,80 @@
+package openhue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFindLight(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[
+		{"id": "light-1", "metadata": {"name": "Kitchen Ceiling"}},
+		{"id": "light-2", "metadata": {"name": "Kitchen Strip"}},
+		{"id": "light-3", "metadata": {"name": "Desk Lamp"}},
+		{"id": "light-4", "metadata": {"name": "Desk lamp"}}
+	]`), nil)
+
+	tests := []struct {
+		name    string
+		query   string
+		opts    []FindOption
+		wantId  string
+		wantErr error
+	}{
+		{"exact case-insensitive", "kitchen  CEILING", nil, "light-1", nil},
+		{"not found without fuzzy", "ceiling", nil, "", ErrNotFound},
+		{"fuzzy words", "ceiling", []FindOption{WithFuzzyMatch()}, "light-1", nil},
+		{"fuzzy typo", "kitchen strp", []FindOption{WithFuzzyMatch()}, "light-2", nil},
+		{"fuzzy ambiguous", "kitchen", []FindOption{WithFuzzyMatch()}, "", ErrAmbiguousName},
+		{"duplicate names", "desk lamp", nil, "", ErrAmbiguousName},
+		{"fuzzy not found", "bedroom", []FindOption{WithFuzzyMatch()}, "", ErrNotFound},
+		{"empty", "", nil, "", ErrEmptyName},
+		{"fuzzy blank", "  ", []FindOption{WithFuzzyMatch()}, "", ErrEmptyName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			light, err := home.FindLight(context.Background(), tt.query, tt.opts...)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "unexpected error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantId, *light.Id)
+		})
+	}
+}
+
+func TestFindLight_AmbiguousCandidates(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[
+		{"id": "light-2", "metadata": {"name": "Desk Lamp"}},
+		{"id": "light-1", "metadata": {"name": "Desk Lamp"}}
+	]`), nil)
+
+	_, err := home.FindLight(context.Background(), "desk lamp")
+
+	var ambiguous *AmbiguousNameError
+	assert.True(t, errors.As(err, &ambiguous))
+	assert.Equal(t, []NamedResource{{Id: "light-1", Name: "Desk Lamp"}, {Id: "light-2", Name: "Desk Lamp"}}, ambiguous.Candidates)
+	assert.ErrorContains(t, err, `ambiguous light name "desk lamp"`)
+}
+
+func TestFindScene_ScopedByGroup(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetScenesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetScenesResponse](t, `[
+		{"id": "scene-1", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Relax"}},
+		{"id": "scene-2", "group": {"rid": "room-2", "rtype": "room"}, "metadata": {"name": "Relax"}}
+	]`), nil)
+
+	scene, err := home.FindScene(context.Background(), "room-2", "relax")
+	assert.NoError(t, err)
+	assert.Equal(t, "scene-2", *scene.Id)
+}
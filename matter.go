@@ -0,0 +1,146 @@
+package openhue
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// MATTER
+//--------------------------------------------------------------------------------------------------------------------//
+
+// MatterStatus is the state of the Matter integration of the bridge.
+type MatterStatus struct {
+	// Id is the ID of the matter resource.
+	Id string
+	// HasQrCode is true when a QR code is available to commission the bridge into a Matter fabric.
+	HasQrCode bool
+	// MaxFabrics is the maximum number of fabrics the bridge can join.
+	MaxFabrics int
+	// Fabrics are the Matter controllers the bridge is shared with, sorted by creation time.
+	Fabrics []MatterFabric
+}
+
+// MatterFabric is a Matter controller the bridge has been commissioned into.
+type MatterFabric struct {
+	Id     string
+	Label  string
+	Status MatterFabricGetStatus
+	// VendorId is the Matter vendor ID of the commissioning controller.
+	VendorId int
+	// Vendor is the name of the vendor for well known vendor IDs, empty otherwise.
+	Vendor    string
+	CreatedAt *time.Time
+}
+
+// matterVendors are the names of the vendors of the most common Matter controllers, by vendor ID.
+var matterVendors = map[int]string{
+	0x100B: "Signify",
+	0x110A: "Samsung SmartThings",
+	0x1217: "Amazon",
+	0x1349: "Apple",
+	0x6006: "Google",
+}
+
+// GetMatterStatus returns the state of the Matter integration of the bridge and the fabrics it is part of.
+// It returns an error wrapping ErrNotFound if the bridge does not support Matter.
+//
+// Example:
+//
+//	status, err := home.GetMatterStatus(ctx)
+//	fmt.Printf("%d/%d fabrics\n", len(status.Fabrics), status.MaxFabrics)
+func (h *Home) GetMatterStatus(ctx context.Context) (*MatterStatus, error) {
+
+	matter, err := h.getMatter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fabrics, err := h.GetMatterFabrics(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MatterStatus{Id: *matter.Id}
+	if matter.HasQrCode != nil {
+		status.HasQrCode = *matter.HasQrCode
+	}
+	if matter.MaxFabrics != nil {
+		status.MaxFabrics = *matter.MaxFabrics
+	}
+
+	for _, id := range sortedIds(fabrics) {
+		status.Fabrics = append(status.Fabrics, newMatterFabric(fabrics[id]))
+	}
+	sort.SliceStable(status.Fabrics, func(i, j int) bool {
+		a, b := status.Fabrics[i].CreatedAt, status.Fabrics[j].CreatedAt
+		return a != nil && (b == nil || a.Before(*b))
+	})
+
+	return status, nil
+}
+
+// RemoveMatterFabric removes the bridge from a Matter fabric, so that the controller of the fabric no longer has
+// access to it.
+func (h *Home) RemoveMatterFabric(ctx context.Context, matterFabricId string) error {
+
+	// The API specification has no operation to delete a Matter fabric
+	_, err := h.sendResourceRequest(ctx, http.MethodDelete, ResourceIdentifierRtypeMatterFabric, matterFabricId, nil)
+	return err
+}
+
+// ResetMatter resets the Matter integration of the bridge, removing it from all of its fabrics. As it cannot be undone,
+// confirm must be true, otherwise an error wrapping ErrNotConfirmed is returned and nothing is done.
+func (h *Home) ResetMatter(ctx context.Context, confirm bool) error {
+
+	if !confirm {
+		return fmt.Errorf("matter reset: %w", ErrNotConfirmed)
+	}
+
+	matter, err := h.getMatter(ctx)
+	if err != nil {
+		return err
+	}
+
+	reset := MatterReset
+	return h.UpdateMatter(ctx, *matter.Id, MatterPut{Action: &struct {
+		ActionType *MatterPutActionActionType `json:"action_type,omitempty"`
+	}{ActionType: &reset}})
+}
+
+// getMatter returns the matter resource of the bridge, which has at most one.
+func (h *Home) getMatter(ctx context.Context) (*MatterGet, error) {
+
+	matters, err := h.GetMatters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := sortedIds(matters)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("matter: %w", ErrNotFound)
+	}
+
+	matter := matters[ids[0]]
+	return &matter, nil
+}
+
+func newMatterFabric(f MatterFabricGet) MatterFabric {
+	fabric := MatterFabric{Id: *f.Id, CreatedAt: f.CreationTime}
+	if f.Status != nil {
+		fabric.Status = *f.Status
+	}
+	if f.FabricData != nil {
+		if f.FabricData.Label != nil {
+			fabric.Label = *f.FabricData.Label
+		}
+		if f.FabricData.VendorId != nil {
+			fabric.VendorId = *f.FabricData.VendorId
+			fabric.Vendor = matterVendors[fabric.VendorId]
+		}
+	}
+	return fabric
+}
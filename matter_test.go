@@ -0,0 +1,80 @@
+package openhue
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockMatter(t *testing.T, m *ClientWithResponsesMock) {
+	m.On("GetMattersWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetMattersResponse](t, `[{"id": "matter-1", "has_qr_code": true, "max_fabrics": 5}]`), nil)
+
+	m.On("GetMatterFabricsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetMatterFabricsResponse](t, `[
+		{"id": "fabric-b", "status": "paired", "creation_time": "2024-03-01T10:00:00Z", "fabric_data": {"label": "Home", "vendor_id": 4937}},
+		{"id": "fabric-a", "status": "timedout", "creation_time": "2024-05-01T10:00:00Z", "fabric_data": {"vendor_id": 65521}}
+	]`), nil)
+}
+
+func TestGetMatterStatus(t *testing.T) {
+	home, m := NewTestHome()
+	mockMatter(t, m)
+
+	status, err := home.GetMatterStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "matter-1", status.Id)
+	assert.True(t, status.HasQrCode)
+	assert.Equal(t, 5, status.MaxFabrics)
+
+	assert.Len(t, status.Fabrics, 2)
+	assert.Equal(t, "fabric-b", status.Fabrics[0].Id)
+	assert.Equal(t, "Home", status.Fabrics[0].Label)
+	assert.Equal(t, "Apple", status.Fabrics[0].Vendor)
+	assert.Equal(t, MatterFabricGetStatusPaired, status.Fabrics[0].Status)
+	assert.Equal(t, 65521, status.Fabrics[1].VendorId)
+	assert.Empty(t, status.Fabrics[1].Vendor)
+}
+
+func TestGetMatterStatus_Unsupported(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetMattersWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetMattersResponse](t, `[]`), nil)
+
+	_, err := home.GetMatterStatus(context.Background())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestRemoveMatterFabric(t *testing.T) {
+	home, _ := NewTestHome()
+
+	withTestServer(t, home, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/clip/v2/resource/matter_fabric/fabric-a", r.URL.Path)
+		assert.Equal(t, http.NoBody, r.Body)
+		_, _ = w.Write([]byte(`{"data": [{"rid": "fabric-a", "rtype": "matter_fabric"}], "errors": []}`))
+	})
+
+	err := home.RemoveMatterFabric(context.Background(), "fabric-a")
+	assert.NoError(t, err)
+}
+
+func TestResetMatter(t *testing.T) {
+	home, m := NewTestHome()
+	mockMatter(t, m)
+	m.On("UpdateMatterWithResponse", mock.Anything, "matter-1", mock.Anything, mock.Anything).Return(&UpdateMatterResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	err := home.ResetMatter(context.Background(), false)
+	assert.ErrorIs(t, err, ErrNotConfirmed)
+	m.AssertNotCalled(t, "UpdateMatterWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	err = home.ResetMatter(context.Background(), true)
+	assert.NoError(t, err)
+
+	m.AssertCalled(t, "UpdateMatterWithResponse", mock.Anything, "matter-1", mock.MatchedBy(func(body MatterPut) bool {
+		return *body.Action.ActionType == MatterReset
+	}), mock.Anything)
+}
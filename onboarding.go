@@ -0,0 +1,207 @@
+package openhue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// DEVICE ONBOARDING
+//--------------------------------------------------------------------------------------------------------------------//
+
+type searchConfig struct {
+	serials      []string
+	pollInterval time.Duration
+}
+
+// SearchOption is a functional option for configuring SearchDevices.
+type SearchOption func(*searchConfig)
+
+// WithSearchSerials makes the bridge search for the devices having the given serial numbers, as printed on the
+// devices or their packaging. This is needed for devices that have already been paired with another bridge.
+func WithSearchSerials(serials ...string) SearchOption {
+	return func(c *searchConfig) {
+		c.serials = append(c.serials, serials...)
+	}
+}
+
+// WithSearchPollInterval sets how often the status of the search is polled. It defaults to 5 seconds. Values lower
+// than or equal to 0 are ignored.
+func WithSearchPollInterval(d time.Duration) SearchOption {
+	return func(c *searchConfig) {
+		if d > 0 {
+			c.pollInterval = d
+		}
+	}
+}
+
+// zigbeeSearchBody is a ZigbeeDeviceDiscoveryPut also carrying the serial numbers to search for, which the generated
+// model does not support.
+type zigbeeSearchBody struct {
+	Action struct {
+		ActionType  ZigbeeDeviceDiscoveryPutActionActionType `json:"action_type"`
+		SearchCodes []string                                 `json:"search_codes,omitempty"`
+	} `json:"action"`
+}
+
+// SearchDevices starts a Zigbee device search on the bridge, waits for it to finish and returns the devices that
+// joined the bridge in the meantime, sorted by ID. A search lasts about a minute. Lights should be powered on right
+// before calling it, while other devices usually need to be put in pairing mode.
+//
+// Example:
+//
+//	devices, err := home.SearchDevices(ctx, openhue.WithSearchSerials("AB12CD"))
+//	for _, device := range devices {
+//		fmt.Println(*device.Id, *device.ProductData.ProductName)
+//	}
+func (h *Home) SearchDevices(ctx context.Context, opts ...SearchOption) ([]DeviceGet, error) {
+
+	cfg := &searchConfig{pollInterval: 5 * time.Second}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	known, err := h.GetDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	discoveries, err := h.GetZigbeeDeviceDiscoveries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := sortedIds(discoveries)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("zigbee device discovery: %w", ErrNotFound)
+	}
+	discoveryId := ids[0]
+
+	if err := h.startSearch(ctx, discoveryId, cfg.serials); err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(cfg.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		discovery, err := h.GetZigbeeDeviceDiscoveryById(ctx, discoveryId)
+		if err != nil {
+			return nil, err
+		}
+		if discovery.Status == nil || *discovery.Status != Active {
+			break
+		}
+	}
+
+	devices, err := h.GetDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var joined []DeviceGet
+	for _, id := range sortedIds(devices) {
+		if _, ok := known[id]; !ok {
+			joined = append(joined, devices[id])
+		}
+	}
+
+	return joined, nil
+}
+
+func (h *Home) startSearch(ctx context.Context, discoveryId string, serials []string) error {
+
+	if len(serials) == 0 {
+		search := Search
+		return h.UpdateZigbeeDeviceDiscovery(ctx, discoveryId, ZigbeeDeviceDiscoveryPut{Action: &struct {
+			ActionType *ZigbeeDeviceDiscoveryPutActionActionType `json:"action_type,omitempty"`
+		}{ActionType: &search}})
+	}
+
+	body := zigbeeSearchBody{}
+	body.Action.ActionType = Search
+	body.Action.SearchCodes = serials
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.api.UpdateZigbeeDeviceDiscoveryWithBodyWithResponse(ctx, discoveryId, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+// OnboardDevices names the given devices and assigns them to a room. The names are indexed by device ID, an empty
+// name keeping the current one. The room is left untouched if roomId is empty.
+//
+// Example:
+//
+//	devices, err := home.SearchDevices(ctx)
+//	err = home.OnboardDevices(ctx, kitchenId, map[string]string{
+//		*devices[0].Id: "Kitchen ceiling",
+//	})
+func (h *Home) OnboardDevices(ctx context.Context, roomId string, names map[string]string) error {
+
+	for _, id := range sortedIds(names) {
+		name := names[id]
+		if name == "" {
+			continue
+		}
+		err := h.UpdateDevice(ctx, id, DevicePut{Metadata: &struct {
+			Archetype *ProductArchetype `json:"archetype,omitempty"`
+			Name      *string           `json:"name,omitempty"`
+		}{Name: &name}})
+		if err != nil {
+			return fmt.Errorf("device %s: %w", id, err)
+		}
+	}
+
+	if roomId == "" {
+		return nil
+	}
+
+	room, err := h.GetRoomById(ctx, roomId)
+	if err != nil {
+		return err
+	}
+
+	var children []ResourceIdentifier
+	if room.Children != nil {
+		children = append(children, *room.Children...)
+	}
+	existing := groupChildren(*room, ResourceIdentifierRtypeDevice)
+
+	added := false
+	for _, id := range sortedIds(names) {
+		if slices.Contains(existing, id) {
+			continue
+		}
+		rid, rtype := id, ResourceIdentifierRtypeDevice
+		children = append(children, ResourceIdentifier{Rid: &rid, Rtype: &rtype})
+		added = true
+	}
+
+	if !added {
+		return nil
+	}
+
+	return h.UpdateRoom(ctx, roomId, RoomPut{Children: &children})
+}
@@ -0,0 +1,93 @@
+package openhue
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockSearch(t *testing.T, m *ClientWithResponsesMock) {
+	m.On("GetDevicesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetDevicesResponse](t, `[{"id": "dev-1"}]`), nil).Once()
+	m.On("GetDevicesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetDevicesResponse](t, `[
+		{"id": "dev-1"},
+		{"id": "dev-3"},
+		{"id": "dev-2"}
+	]`), nil).Once()
+	m.On("GetZigbeeDeviceDiscoveriesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetZigbeeDeviceDiscoveriesResponse](t, `[{"id": "zdd-1", "status": "ready"}]`), nil)
+	m.On("GetZigbeeDeviceDiscoveryWithResponse", mock.Anything, "zdd-1", mock.Anything).Return(dataResponse[*GetZigbeeDeviceDiscoveryResponse](t, `[{"id": "zdd-1", "status": "active"}]`), nil).Once()
+	m.On("GetZigbeeDeviceDiscoveryWithResponse", mock.Anything, "zdd-1", mock.Anything).Return(dataResponse[*GetZigbeeDeviceDiscoveryResponse](t, `[{"id": "zdd-1", "status": "ready"}]`), nil).Once()
+}
+
+func TestSearchDevices(t *testing.T) {
+	home, m := NewTestHome()
+	mockSearch(t, m)
+	m.On("UpdateZigbeeDeviceDiscoveryWithResponse", mock.Anything, "zdd-1", mock.Anything, mock.Anything).Return(&UpdateZigbeeDeviceDiscoveryResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	devices, err := home.SearchDevices(context.Background(), WithSearchPollInterval(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Len(t, devices, 2)
+	assert.Equal(t, "dev-2", *devices[0].Id)
+	assert.Equal(t, "dev-3", *devices[1].Id)
+
+	m.AssertCalled(t, "UpdateZigbeeDeviceDiscoveryWithResponse", mock.Anything, "zdd-1", mock.MatchedBy(func(body ZigbeeDeviceDiscoveryPut) bool {
+		return *body.Action.ActionType == Search
+	}), mock.Anything)
+	m.AssertNumberOfCalls(t, "GetZigbeeDeviceDiscoveryWithResponse", 2)
+}
+
+func TestSearchDevices_Serials(t *testing.T) {
+	home, m := NewTestHome()
+	mockSearch(t, m)
+
+	var sent map[string]any
+	m.On("UpdateZigbeeDeviceDiscoveryWithBodyWithResponse", mock.Anything, "zdd-1", "application/json", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			data, _ := io.ReadAll(args.Get(3).(io.Reader))
+			_ = json.Unmarshal(data, &sent)
+		}).
+		Return(&UpdateZigbeeDeviceDiscoveryResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	devices, err := home.SearchDevices(context.Background(), WithSearchPollInterval(time.Millisecond), WithSearchSerials("AB12CD", "EF34GH"))
+	assert.NoError(t, err)
+	assert.Len(t, devices, 2)
+
+	assert.Equal(t, map[string]any{
+		"action": map[string]any{"action_type": "search", "search_codes": []any{"AB12CD", "EF34GH"}},
+	}, sent)
+	m.AssertNotCalled(t, "UpdateZigbeeDeviceDiscoveryWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOnboardDevices(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetRoomWithResponse", mock.Anything, "room-1", mock.Anything).Return(dataResponse[*GetRoomResponse](t, `[
+		{"id": "room-1", "metadata": {"name": "Kitchen"}, "children": [{"rid": "dev-1", "rtype": "device"}]}
+	]`), nil)
+
+	ok := &http.Response{StatusCode: http.StatusOK}
+	m.On("UpdateDeviceWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&UpdateDeviceResponse{HTTPResponse: ok}, nil)
+	m.On("UpdateRoomWithResponse", mock.Anything, "room-1", mock.Anything, mock.Anything).Return(&UpdateRoomResponse{HTTPResponse: ok}, nil)
+
+	err := home.OnboardDevices(context.Background(), "room-1", map[string]string{
+		"dev-1": "",
+		"dev-2": "Ceiling",
+	})
+	assert.NoError(t, err)
+
+	m.AssertNumberOfCalls(t, "UpdateDeviceWithResponse", 1)
+	m.AssertCalled(t, "UpdateDeviceWithResponse", mock.Anything, "dev-2", mock.MatchedBy(func(body DevicePut) bool {
+		return *body.Metadata.Name == "Ceiling"
+	}), mock.Anything)
+	m.AssertCalled(t, "UpdateRoomWithResponse", mock.Anything, "room-1", mock.MatchedBy(func(body RoomPut) bool {
+		children := *body.Children
+		return len(children) == 2 && *children[0].Rid == "dev-1" && *children[1].Rid == "dev-2"
+	}), mock.Anything)
+}
@@ -11,6 +11,8 @@ import (
 
 type Home struct {
 	api ClientWithResponsesInterface
+	// client sends the requests of the bridge operations missing from the API specification.
+	client *Client
 }
 
 // homeConfig holds the configuration options for creating a Home instance.
@@ -87,8 +89,11 @@ func NewHome(bridgeIP, apiKey string, opts ...HomeOption) (*Home, error) {
 		return nil, err
 	}
 
+	raw, _ := client.ClientInterface.(*Client)
+
 	return &Home{
-		api: client,
+		api:    client,
+		client: raw,
 	}, nil
 }
 
@@ -184,6 +189,19 @@ func (h *Home) GetDeviceById(ctx context.Context, deviceId string) (*DeviceGet,
 	return &data[0], nil
 }
 
+func (h *Home) UpdateDevice(ctx context.Context, deviceId string, body DevicePut) error {
+	resp, err := h.api.UpdateDeviceWithResponse(ctx, deviceId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
 //--------------------------------------------------------------------------------------------------------------------//
 // ROOM
 //--------------------------------------------------------------------------------------------------------------------//
@@ -576,13 +594,27 @@ func (h *Home) DeleteScene(ctx context.Context, sceneId string) error {
 }
 
 // ActivateScene activates a scene by its ID.
-func (h *Home) ActivateScene(ctx context.Context, sceneId string) error {
+// It accepts optional SceneRecallOption functions to customize the recall, for instance the transition duration.
+//
+// Example:
+//
+//	// Fade in over 10 minutes at 50% brightness
+//	err := home.ActivateScene(ctx, sceneId, openhue.WithSceneTransition(10*time.Minute), openhue.WithSceneBrightness(50))
+func (h *Home) ActivateScene(ctx context.Context, sceneId string, opts ...SceneRecallOption) error {
 	action := SceneRecallActionActive
-	return h.UpdateScene(ctx, sceneId, ScenePut{
+	body := ScenePut{
 		Recall: &SceneRecall{
 			Action: &action,
 		},
-	})
+	}
+
+	for _, opt := range opts {
+		if err := opt(&body); err != nil {
+			return err
+		}
+	}
+
+	return h.UpdateScene(ctx, sceneId, body)
 }
 
 //--------------------------------------------------------------------------------------------------------------------//
@@ -713,6 +745,48 @@ func (h *Home) GetButtonById(ctx context.Context, buttonId string) (*ButtonGet,
 	return &data[0], nil
 }
 
+//--------------------------------------------------------------------------------------------------------------------//
+// RELATIVE ROTARY
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetRelativeRotaries(ctx context.Context) (map[string]RelativeRotaryGet, error) {
+	resp, err := h.api.GetRelativeRotariesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	rotaries := make(map[string]RelativeRotaryGet)
+
+	for _, rotary := range data {
+		rotaries[*rotary.Id] = rotary
+	}
+
+	return rotaries, nil
+}
+
+func (h *Home) GetRelativeRotaryById(ctx context.Context, relativeRotaryId string) (*RelativeRotaryGet, error) {
+	resp, err := h.api.GetRelativeRotaryWithResponse(ctx, relativeRotaryId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
 //--------------------------------------------------------------------------------------------------------------------//
 // MOTION SENSOR
 //--------------------------------------------------------------------------------------------------------------------//
@@ -798,11 +872,11 @@ func (h *Home) GetTemperatureSensorById(ctx context.Context, temperatureId strin
 }
 
 //--------------------------------------------------------------------------------------------------------------------//
-// ENTERTAINMENT CONFIGURATION
+// ZIGBEE DEVICE DISCOVERY
 //--------------------------------------------------------------------------------------------------------------------//
 
-func (h *Home) GetEntertainmentConfigurations(ctx context.Context) (map[string]EntertainmentConfigurationGet, error) {
-	resp, err := h.api.GetEntertainmentConfigurationsWithResponse(ctx)
+func (h *Home) GetZigbeeDeviceDiscoveries(ctx context.Context) (map[string]ZigbeeDeviceDiscoveryGet, error) {
+	resp, err := h.api.GetZigbeeDeviceDiscoveriesWithResponse(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -812,17 +886,17 @@ func (h *Home) GetEntertainmentConfigurations(ctx context.Context) (map[string]E
 	}
 
 	data := *(*resp.JSON200).Data
-	configs := make(map[string]EntertainmentConfigurationGet)
+	discoveries := make(map[string]ZigbeeDeviceDiscoveryGet)
 
-	for _, config := range data {
-		configs[*config.Id] = config
+	for _, discovery := range data {
+		discoveries[*discovery.Id] = discovery
 	}
 
-	return configs, nil
+	return discoveries, nil
 }
 
-func (h *Home) GetEntertainmentConfigurationById(ctx context.Context, entertainmentConfigurationId string) (*EntertainmentConfigurationGet, error) {
-	resp, err := h.api.GetEntertainmentConfigurationWithResponse(ctx, entertainmentConfigurationId)
+func (h *Home) GetZigbeeDeviceDiscoveryById(ctx context.Context, zigbeeDeviceDiscoveryId string) (*ZigbeeDeviceDiscoveryGet, error) {
+	resp, err := h.api.GetZigbeeDeviceDiscoveryWithResponse(ctx, zigbeeDeviceDiscoveryId)
 	if err != nil {
 		return nil, err
 	}
@@ -839,24 +913,105 @@ func (h *Home) GetEntertainmentConfigurationById(ctx context.Context, entertainm
 	return &data[0], nil
 }
 
-// StartEntertainment starts entertainment mode for the given configuration.
-func (h *Home) StartEntertainment(ctx context.Context, entertainmentConfigurationId string) error {
-	action := EntertainmentConfigurationPutActionStart
-	return h.UpdateEntertainmentConfiguration(ctx, entertainmentConfigurationId, EntertainmentConfigurationPut{
-		Action: &action,
-	})
+func (h *Home) UpdateZigbeeDeviceDiscovery(ctx context.Context, zigbeeDeviceDiscoveryId string, body ZigbeeDeviceDiscoveryPut) error {
+	resp, err := h.api.UpdateZigbeeDeviceDiscoveryWithResponse(ctx, zigbeeDeviceDiscoveryId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
 }
 
-// StopEntertainment stops entertainment mode for the given configuration.
-func (h *Home) StopEntertainment(ctx context.Context, entertainmentConfigurationId string) error {
-	action := EntertainmentConfigurationPutActionStop
-	return h.UpdateEntertainmentConfiguration(ctx, entertainmentConfigurationId, EntertainmentConfigurationPut{
-		Action: &action,
-	})
+//--------------------------------------------------------------------------------------------------------------------//
+// BEHAVIOR SCRIPT
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetBehaviorScripts(ctx context.Context) (map[string]BehaviorScriptGet, error) {
+	resp, err := h.api.GetBehaviorScriptsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	scripts := make(map[string]BehaviorScriptGet)
+
+	for _, script := range data {
+		scripts[*script.Id] = script
+	}
+
+	return scripts, nil
 }
 
-func (h *Home) UpdateEntertainmentConfiguration(ctx context.Context, entertainmentConfigurationId string, body EntertainmentConfigurationPut) error {
-	resp, err := h.api.UpdateEntertainmentConfigurationWithResponse(ctx, entertainmentConfigurationId, body)
+func (h *Home) GetBehaviorScriptById(ctx context.Context, behaviorScriptId string) (*BehaviorScriptGet, error) {
+	resp, err := h.api.GetBehaviorScriptWithResponse(ctx, behaviorScriptId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// BEHAVIOR INSTANCE
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetBehaviorInstances(ctx context.Context) (map[string]BehaviorInstanceGet, error) {
+	resp, err := h.api.GetBehaviorInstancesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	instances := make(map[string]BehaviorInstanceGet)
+
+	for _, instance := range data {
+		instances[*instance.Id] = instance
+	}
+
+	return instances, nil
+}
+
+func (h *Home) GetBehaviorInstanceById(ctx context.Context, behaviorInstanceId string) (*BehaviorInstanceGet, error) {
+	resp, err := h.api.GetBehaviorInstanceWithResponse(ctx, behaviorInstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateBehaviorInstance(ctx context.Context, behaviorInstanceId string, body BehaviorInstancePut) error {
+	resp, err := h.api.UpdateBehaviorInstanceWithResponse(ctx, behaviorInstanceId, body)
 	if err != nil {
 		return err
 	}
@@ -869,11 +1024,11 @@ func (h *Home) UpdateEntertainmentConfiguration(ctx context.Context, entertainme
 }
 
 //--------------------------------------------------------------------------------------------------------------------//
-// BRIDGE
+// GEOFENCE CLIENT
 //--------------------------------------------------------------------------------------------------------------------//
 
-func (h *Home) GetBridges(ctx context.Context) (map[string]BridgeGet, error) {
-	resp, err := h.api.GetBridgesWithResponse(ctx)
+func (h *Home) GetGeofenceClients(ctx context.Context) (map[string]GeofenceClientGet, error) {
+	resp, err := h.api.GetGeofenceClientsWithResponse(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -883,35 +1038,90 @@ func (h *Home) GetBridges(ctx context.Context) (map[string]BridgeGet, error) {
 	}
 
 	data := *(*resp.JSON200).Data
-	bridges := make(map[string]BridgeGet)
+	clients := make(map[string]GeofenceClientGet)
 
-	for _, bridge := range data {
-		bridges[*bridge.Id] = bridge
+	for _, client := range data {
+		clients[*client.Id] = client
 	}
 
-	return bridges, nil
+	return clients, nil
 }
 
-func (h *Home) GetBridge(ctx context.Context) (*BridgeGet, error) {
-	bridges, err := h.GetBridges(ctx)
+func (h *Home) GetGeofenceClientById(ctx context.Context, geofenceClientId string) (*GeofenceClientGet, error) {
+	resp, err := h.api.GetGeofenceClientWithResponse(ctx, geofenceClientId)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(bridges) == 0 {
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
 		return nil, ErrEmptyResponse
 	}
 
-	// Return the first (and typically only) bridge
-	for _, bridge := range bridges {
-		return &bridge, nil
+	return &data[0], nil
+}
+
+func (h *Home) UpdateGeofenceClient(ctx context.Context, geofenceClientId string, body GeofenceClientPut) error {
+	resp, err := h.api.UpdateGeofenceClientWithResponse(ctx, geofenceClientId, body)
+	if err != nil {
+		return err
 	}
 
-	return nil, ErrEmptyResponse
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
 }
 
-func (h *Home) UpdateBridge(ctx context.Context, bridgeId string, body BridgePut) error {
-	resp, err := h.api.UpdateBridgeWithResponse(ctx, bridgeId, body)
+//--------------------------------------------------------------------------------------------------------------------//
+// GEOLOCATION
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetGeolocations(ctx context.Context) (map[string]GeolocationGet, error) {
+	resp, err := h.api.GetGeolocationsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	geolocations := make(map[string]GeolocationGet)
+
+	for _, geolocation := range data {
+		geolocations[*geolocation.Id] = geolocation
+	}
+
+	return geolocations, nil
+}
+
+func (h *Home) GetGeolocationById(ctx context.Context, geolocationId string) (*GeolocationGet, error) {
+	resp, err := h.api.GetGeolocationWithResponse(ctx, geolocationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateGeolocation(ctx context.Context, geolocationId string, body GeolocationPut) error {
+	resp, err := h.api.UpdateGeolocationWithResponse(ctx, geolocationId, body)
 	if err != nil {
 		return err
 	}
@@ -924,11 +1134,11 @@ func (h *Home) UpdateBridge(ctx context.Context, bridgeId string, body BridgePut
 }
 
 //--------------------------------------------------------------------------------------------------------------------//
-// DEVICE POWER
+// HOMEKIT
 //--------------------------------------------------------------------------------------------------------------------//
 
-func (h *Home) GetDevicePowers(ctx context.Context) (map[string]DevicePowerGet, error) {
-	resp, err := h.api.GetDevicePowersWithResponse(ctx)
+func (h *Home) GetHomekits(ctx context.Context) (map[string]HomekitGet, error) {
+	resp, err := h.api.GetHomekitsWithResponse(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -938,17 +1148,862 @@ func (h *Home) GetDevicePowers(ctx context.Context) (map[string]DevicePowerGet,
 	}
 
 	data := *(*resp.JSON200).Data
-	powers := make(map[string]DevicePowerGet)
+	homekits := make(map[string]HomekitGet)
 
-	for _, power := range data {
-		powers[*power.Id] = power
+	for _, homekit := range data {
+		homekits[*homekit.Id] = homekit
 	}
 
-	return powers, nil
+	return homekits, nil
 }
 
-func (h *Home) GetDevicePowerById(ctx context.Context, devicePowerId string) (*DevicePowerGet, error) {
-	resp, err := h.api.GetDevicePowerWithResponse(ctx, devicePowerId)
+func (h *Home) GetHomekitById(ctx context.Context, homekitId string) (*HomekitGet, error) {
+	resp, err := h.api.GetHomekitWithResponse(ctx, homekitId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateHomekit(ctx context.Context, homekitId string, body HomekitPut) error {
+	resp, err := h.api.UpdateHomekitWithResponse(ctx, homekitId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// LIGHT LEVEL SENSOR
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetLightLevelSensors(ctx context.Context) (map[string]LightLevelGet, error) {
+	resp, err := h.api.GetLightLevelsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	sensors := make(map[string]LightLevelGet)
+
+	for _, sensor := range data {
+		sensors[*sensor.Id] = sensor
+	}
+
+	return sensors, nil
+}
+
+func (h *Home) GetLightLevelSensorById(ctx context.Context, lightLevelSensorId string) (*LightLevelGet, error) {
+	resp, err := h.api.GetLightLevelWithResponse(ctx, lightLevelSensorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+// UpdateLightLevelSensor enables or disables a light level sensor.
+func (h *Home) UpdateLightLevelSensor(ctx context.Context, lightLevelSensorId string, body LightLevelPut) error {
+	resp, err := h.api.UpdateLightLevelWithResponse(ctx, lightLevelSensorId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// CONTACT SENSOR
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetContactSensors(ctx context.Context) (map[string]ContactGet, error) {
+	resp, err := h.api.GetContactsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	sensors := make(map[string]ContactGet)
+
+	for _, sensor := range data {
+		sensors[*sensor.Id] = sensor
+	}
+
+	return sensors, nil
+}
+
+func (h *Home) GetContactSensorById(ctx context.Context, contactSensorId string) (*ContactGet, error) {
+	resp, err := h.api.GetContactWithResponse(ctx, contactSensorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateContactSensor(ctx context.Context, contactSensorId string, body ContactPut) error {
+	resp, err := h.api.UpdateContactWithResponse(ctx, contactSensorId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// TAMPER SENSOR
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetTamperSensors(ctx context.Context) (map[string]TamperGet, error) {
+	resp, err := h.api.GetTampersWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	sensors := make(map[string]TamperGet)
+
+	for _, sensor := range data {
+		sensors[*sensor.Id] = sensor
+	}
+
+	return sensors, nil
+}
+
+func (h *Home) GetTamperSensorById(ctx context.Context, tamperSensorId string) (*TamperGet, error) {
+	resp, err := h.api.GetTamperWithResponse(ctx, tamperSensorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateTamperSensor(ctx context.Context, tamperSensorId string, body TamperPut) error {
+	resp, err := h.api.UpdateTamperWithResponse(ctx, tamperSensorId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// CAMERA MOTION SENSOR
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetCameraMotionSensors(ctx context.Context) (map[string]CameraMotionGet, error) {
+	resp, err := h.api.GetCameraMotionsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	sensors := make(map[string]CameraMotionGet)
+
+	for _, sensor := range data {
+		sensors[*sensor.Id] = sensor
+	}
+
+	return sensors, nil
+}
+
+func (h *Home) GetCameraMotionSensorById(ctx context.Context, cameraMotionSensorId string) (*CameraMotionGet, error) {
+	resp, err := h.api.GetCameraMotionWithResponse(ctx, cameraMotionSensorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateCameraMotionSensor(ctx context.Context, cameraMotionSensorId string, body CameraMotionPut) error {
+	resp, err := h.api.UpdateCameraMotionWithResponse(ctx, cameraMotionSensorId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// GROUPED MOTION
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetGroupedMotions(ctx context.Context) (map[string]GroupedMotionGet, error) {
+	resp, err := h.api.GetGroupedMotionsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	motions := make(map[string]GroupedMotionGet)
+
+	for _, r := range data {
+		motions[*r.Id] = r
+	}
+
+	return motions, nil
+}
+
+func (h *Home) GetGroupedMotionById(ctx context.Context, groupedMotionId string) (*GroupedMotionGet, error) {
+	resp, err := h.api.GetGroupedMotionWithResponse(ctx, groupedMotionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// GROUPED LIGHT LEVEL
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetGroupedLightLevels(ctx context.Context) (map[string]GroupedLightLevelGet, error) {
+	resp, err := h.api.GetGroupedLightLevelsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	levels := make(map[string]GroupedLightLevelGet)
+
+	for _, r := range data {
+		levels[*r.Id] = r
+	}
+
+	return levels, nil
+}
+
+func (h *Home) GetGroupedLightLevelById(ctx context.Context, groupedLightLevelId string) (*GroupedLightLevelGet, error) {
+	resp, err := h.api.GetGroupedLightLevelWithResponse(ctx, groupedLightLevelId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// MATTER
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetMatters(ctx context.Context) (map[string]MatterGet, error) {
+	resp, err := h.api.GetMattersWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	matters := make(map[string]MatterGet)
+
+	for _, matter := range data {
+		matters[*matter.Id] = matter
+	}
+
+	return matters, nil
+}
+
+func (h *Home) GetMatterById(ctx context.Context, matterId string) (*MatterGet, error) {
+	resp, err := h.api.GetMatterWithResponse(ctx, matterId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateMatter(ctx context.Context, matterId string, body MatterPut) error {
+	resp, err := h.api.UpdateMatterWithResponse(ctx, matterId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// MATTER FABRIC
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetMatterFabrics(ctx context.Context) (map[string]MatterFabricGet, error) {
+	resp, err := h.api.GetMatterFabricsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	fabrics := make(map[string]MatterFabricGet)
+
+	for _, fabric := range data {
+		fabrics[*fabric.Id] = fabric
+	}
+
+	return fabrics, nil
+}
+
+func (h *Home) GetMatterFabricById(ctx context.Context, matterFabricId string) (*MatterFabricGet, error) {
+	resp, err := h.api.GetMatterFabricWithResponse(ctx, matterFabricId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// MOTION AREA
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetMotionAreaConfigurations(ctx context.Context) (map[string]MotionAreaConfigurationGet, error) {
+	resp, err := h.api.GetMotionAreaConfigurationsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	configurations := make(map[string]MotionAreaConfigurationGet)
+
+	for _, r := range data {
+		configurations[*r.Id] = r
+	}
+
+	return configurations, nil
+}
+
+func (h *Home) GetMotionAreaConfigurationById(ctx context.Context, motionAreaConfigurationId string) (*MotionAreaConfigurationGet, error) {
+	resp, err := h.api.GetMotionAreaConfigurationWithResponse(ctx, motionAreaConfigurationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateMotionAreaConfiguration(ctx context.Context, motionAreaConfigurationId string, body MotionAreaConfigurationPut) error {
+	resp, err := h.api.UpdateMotionAreaConfigurationWithResponse(ctx, motionAreaConfigurationId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// CONVENIENCE AREA MOTION
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetConvenienceAreaMotions(ctx context.Context) (map[string]ConvenienceAreaMotionGet, error) {
+	resp, err := h.api.GetConvenienceAreaMotionsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	motions := make(map[string]ConvenienceAreaMotionGet)
+
+	for _, r := range data {
+		motions[*r.Id] = r
+	}
+
+	return motions, nil
+}
+
+func (h *Home) GetConvenienceAreaMotionById(ctx context.Context, convenienceAreaMotionId string) (*ConvenienceAreaMotionGet, error) {
+	resp, err := h.api.GetConvenienceAreaMotionWithResponse(ctx, convenienceAreaMotionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateConvenienceAreaMotion(ctx context.Context, convenienceAreaMotionId string, body ConvenienceAreaMotionPut) error {
+	resp, err := h.api.UpdateConvenienceAreaMotionWithResponse(ctx, convenienceAreaMotionId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SECURITY AREA MOTION
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetSecurityAreaMotions(ctx context.Context) (map[string]SecurityAreaMotionGet, error) {
+	resp, err := h.api.GetSecurityAreaMotionsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	motions := make(map[string]SecurityAreaMotionGet)
+
+	for _, r := range data {
+		motions[*r.Id] = r
+	}
+
+	return motions, nil
+}
+
+func (h *Home) GetSecurityAreaMotionById(ctx context.Context, securityAreaMotionId string) (*SecurityAreaMotionGet, error) {
+	resp, err := h.api.GetSecurityAreaMotionWithResponse(ctx, securityAreaMotionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateSecurityAreaMotion(ctx context.Context, securityAreaMotionId string, body SecurityAreaMotionPut) error {
+	resp, err := h.api.UpdateSecurityAreaMotionWithResponse(ctx, securityAreaMotionId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// ENTERTAINMENT CONFIGURATION
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetEntertainmentConfigurations(ctx context.Context) (map[string]EntertainmentConfigurationGet, error) {
+	resp, err := h.api.GetEntertainmentConfigurationsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	configs := make(map[string]EntertainmentConfigurationGet)
+
+	for _, config := range data {
+		configs[*config.Id] = config
+	}
+
+	return configs, nil
+}
+
+func (h *Home) GetEntertainmentConfigurationById(ctx context.Context, entertainmentConfigurationId string) (*EntertainmentConfigurationGet, error) {
+	resp, err := h.api.GetEntertainmentConfigurationWithResponse(ctx, entertainmentConfigurationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+// StartEntertainment starts entertainment mode for the given configuration.
+func (h *Home) StartEntertainment(ctx context.Context, entertainmentConfigurationId string) error {
+	action := EntertainmentConfigurationPutActionStart
+	return h.UpdateEntertainmentConfiguration(ctx, entertainmentConfigurationId, EntertainmentConfigurationPut{
+		Action: &action,
+	})
+}
+
+// StopEntertainment stops entertainment mode for the given configuration.
+func (h *Home) StopEntertainment(ctx context.Context, entertainmentConfigurationId string) error {
+	action := EntertainmentConfigurationPutActionStop
+	return h.UpdateEntertainmentConfiguration(ctx, entertainmentConfigurationId, EntertainmentConfigurationPut{
+		Action: &action,
+	})
+}
+
+func (h *Home) UpdateEntertainmentConfiguration(ctx context.Context, entertainmentConfigurationId string, body EntertainmentConfigurationPut) error {
+	resp, err := h.api.UpdateEntertainmentConfigurationWithResponse(ctx, entertainmentConfigurationId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// BRIDGE
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetBridges(ctx context.Context) (map[string]BridgeGet, error) {
+	resp, err := h.api.GetBridgesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	bridges := make(map[string]BridgeGet)
+
+	for _, bridge := range data {
+		bridges[*bridge.Id] = bridge
+	}
+
+	return bridges, nil
+}
+
+func (h *Home) GetBridge(ctx context.Context) (*BridgeGet, error) {
+	bridges, err := h.GetBridges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bridges) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	// Return the first (and typically only) bridge
+	for _, bridge := range bridges {
+		return &bridge, nil
+	}
+
+	return nil, ErrEmptyResponse
+}
+
+func (h *Home) UpdateBridge(ctx context.Context, bridgeId string, body BridgePut) error {
+	resp, err := h.api.UpdateBridgeWithResponse(ctx, bridgeId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// DEVICE POWER
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetDevicePowers(ctx context.Context) (map[string]DevicePowerGet, error) {
+	resp, err := h.api.GetDevicePowersWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	powers := make(map[string]DevicePowerGet)
+
+	for _, power := range data {
+		powers[*power.Id] = power
+	}
+
+	return powers, nil
+}
+
+func (h *Home) GetDevicePowerById(ctx context.Context, devicePowerId string) (*DevicePowerGet, error) {
+	resp, err := h.api.GetDevicePowerWithResponse(ctx, devicePowerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// DEVICE SOFTWARE UPDATE
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetDeviceSoftwareUpdates(ctx context.Context) (map[string]DeviceSoftwareUpdateGet, error) {
+	resp, err := h.api.GetDeviceSoftwareUpdatesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	updates := make(map[string]DeviceSoftwareUpdateGet)
+
+	for _, update := range data {
+		updates[*update.Id] = update
+	}
+
+	return updates, nil
+}
+
+func (h *Home) GetDeviceSoftwareUpdateById(ctx context.Context, deviceSoftwareUpdateId string) (*DeviceSoftwareUpdateGet, error) {
+	resp, err := h.api.GetDeviceSoftwareUpdateWithResponse(ctx, deviceSoftwareUpdateId)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	if len(data) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &data[0], nil
+}
+
+func (h *Home) UpdateDeviceSoftwareUpdate(ctx context.Context, deviceSoftwareUpdateId string, body DeviceSoftwareUpdatePut) error {
+	resp, err := h.api.UpdateDeviceSoftwareUpdateWithResponse(ctx, deviceSoftwareUpdateId, body)
+	if err != nil {
+		return err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return newApiError(resp)
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// ZIGBEE CONNECTIVITY
+//--------------------------------------------------------------------------------------------------------------------//
+
+func (h *Home) GetZigbeeConnectivities(ctx context.Context) (map[string]ZigbeeConnectivityGet, error) {
+	resp, err := h.api.GetZigbeeConnectivitiesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	data := *(*resp.JSON200).Data
+	connectivities := make(map[string]ZigbeeConnectivityGet)
+
+	for _, connectivity := range data {
+		connectivities[*connectivity.Id] = connectivity
+	}
+
+	return connectivities, nil
+}
+
+func (h *Home) GetZigbeeConnectivityById(ctx context.Context, zigbeeConnectivityId string) (*ZigbeeConnectivityGet, error) {
+	resp, err := h.api.GetZigbeeConnectivityWithResponse(ctx, zigbeeConnectivityId)
 	if err != nil {
 		return nil, err
 	}
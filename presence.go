@@ -0,0 +1,129 @@
+package openhue
+
+import (
+	"context"
+	"fmt"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// PRESENCE
+//--------------------------------------------------------------------------------------------------------------------//
+
+// Presence is the occupancy of a room or a zone, as aggregated by the bridge from all of its sensors.
+type Presence struct {
+	// GroupId is the ID of the room or the zone.
+	GroupId string
+	// Motion is true when any motion sensor of the group detects motion.
+	Motion bool
+	// MotionValid is true when at least one motion sensor of the group reports valid motion. When false, Motion
+	// should not be trusted, for instance because the sensors are unreachable.
+	MotionValid bool
+	// ConvenienceMotion is the motion detected by the MotionAware convenience area of the group, nil if it has none.
+	ConvenienceMotion *bool
+	// SecurityMotion is the motion detected by the MotionAware security area of the group, nil if it has none.
+	SecurityMotion *bool
+	// Lux is the illuminance measured by the light sensors of the group, nil if unknown or not valid.
+	Lux *float64
+}
+
+// Occupied returns true when valid motion is detected, either by the motion sensors or by a MotionAware area.
+func (p *Presence) Occupied() bool {
+	return (p.Motion && p.MotionValid) ||
+		(p.ConvenienceMotion != nil && *p.ConvenienceMotion) ||
+		(p.SecurityMotion != nil && *p.SecurityMotion)
+}
+
+// RoomPresence returns the occupancy of the room or the zone of the given ID, combining its grouped motion, its
+// grouped light level and its MotionAware areas. It returns an error wrapping ErrNotFound if the group has none of them.
+//
+// Example:
+//
+//	presence, err := home.RoomPresence(ctx, roomId)
+//	if presence.Occupied() && presence.Lux != nil && *presence.Lux < 50 {
+//		// turn the lights on
+//	}
+func (h *Home) RoomPresence(ctx context.Context, groupId string) (*Presence, error) {
+
+	motions, err := h.GetGroupedMotions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	levels, err := h.GetGroupedLightLevels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	configurations, err := h.GetMotionAreaConfigurations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	convenienceMotions, err := h.GetConvenienceAreaMotions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	securityMotions, err := h.GetSecurityAreaMotions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Presence{GroupId: groupId}
+	found := false
+
+	for _, m := range motions {
+		if !ownedBy(m.Owner, groupId) || m.Motion == nil {
+			continue
+		}
+		found = true
+		p.Motion = p.Motion || (m.Motion.Motion != nil && *m.Motion.Motion)
+		p.MotionValid = p.MotionValid || (m.Motion.MotionValid != nil && *m.Motion.MotionValid)
+	}
+
+	for _, l := range levels {
+		if !ownedBy(l.Owner, groupId) || l.Light == nil {
+			continue
+		}
+		found = true
+		if l.Light.LightLevel != nil && l.Light.LightLevelValid != nil && *l.Light.LightLevelValid {
+			lux := LightLevelToLux(*l.Light.LightLevel)
+			p.Lux = &lux
+		}
+	}
+
+	// MotionAware areas belong to an area configuration, which belongs to the group
+	areas := map[string]bool{groupId: true}
+	for id, c := range configurations {
+		if ownedBy(c.Owner, groupId) {
+			areas[id] = true
+		}
+	}
+
+	for _, m := range convenienceMotions {
+		if m.Owner == nil || m.Owner.Rid == nil || !areas[*m.Owner.Rid] || m.Motion == nil {
+			continue
+		}
+		found = true
+		p.ConvenienceMotion = orMotion(p.ConvenienceMotion, m.Motion.Motion)
+	}
+
+	for _, m := range securityMotions {
+		if m.Owner == nil || m.Owner.Rid == nil || !areas[*m.Owner.Rid] || m.Motion == nil {
+			continue
+		}
+		found = true
+		p.SecurityMotion = orMotion(p.SecurityMotion, m.Motion.Motion)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no motion or light level sensing for group %s: %w", groupId, ErrNotFound)
+	}
+
+	return p, nil
+}
+
+func ownedBy(owner *ResourceIdentifier, id string) bool {
+	return owner != nil && owner.Rid != nil && *owner.Rid == id
+}
+
+func orMotion(current *bool, motion *bool) *bool {
+	detected := (current != nil && *current) || (motion != nil && *motion)
+	return &detected
+}
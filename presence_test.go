@@ -0,0 +1,57 @@
+package openhue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockPresence(t *testing.T, m *ClientWithResponsesMock, securityMotions string) {
+	m.On("GetGroupedMotionsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetGroupedMotionsResponse](t, `[
+		{"id": "gm-1", "owner": {"rid": "room-1", "rtype": "room"}, "motion": {"motion": true, "motion_valid": true}},
+		{"id": "gm-2", "owner": {"rid": "room-2", "rtype": "room"}, "motion": {"motion": false, "motion_valid": true}}
+	]`), nil)
+	m.On("GetGroupedLightLevelsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetGroupedLightLevelsResponse](t, `[
+		{"id": "gl-1", "owner": {"rid": "room-1", "rtype": "room"}, "light": {"light_level": 20001, "light_level_valid": true}}
+	]`), nil)
+	m.On("GetMotionAreaConfigurationsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetMotionAreaConfigurationsResponse](t, `[{"id": "area-1", "owner": {"rid": "room-2", "rtype": "room"}}]`), nil)
+	m.On("GetConvenienceAreaMotionsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetConvenienceAreaMotionsResponse](t, `[]`), nil)
+	m.On("GetSecurityAreaMotionsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetSecurityAreaMotionsResponse](t, securityMotions), nil)
+}
+
+func TestRoomPresence(t *testing.T) {
+	home, m := NewTestHome()
+	mockPresence(t, m, `[]`)
+
+	presence, err := home.RoomPresence(context.Background(), "room-1")
+	assert.NoError(t, err)
+
+	assert.True(t, presence.Motion)
+	assert.True(t, presence.MotionValid)
+	assert.True(t, presence.Occupied())
+	assert.Nil(t, presence.SecurityMotion)
+	assert.InDelta(t, 100, *presence.Lux, 0.001)
+}
+
+func TestRoomPresence_MotionAwareArea(t *testing.T) {
+	home, m := NewTestHome()
+	mockPresence(t, m, `[{"id": "sec-1", "owner": {"rid": "area-1"}, "motion": {"motion": true}}]`)
+
+	presence, err := home.RoomPresence(context.Background(), "room-2")
+	assert.NoError(t, err)
+
+	assert.False(t, presence.Motion)
+	assert.Nil(t, presence.Lux)
+	assert.True(t, *presence.SecurityMotion)
+	assert.True(t, presence.Occupied())
+}
+
+func TestRoomPresence_NotFound(t *testing.T) {
+	home, m := NewTestHome()
+	mockPresence(t, m, `[]`)
+
+	_, err := home.RoomPresence(context.Background(), "room-9")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
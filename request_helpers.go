@@ -0,0 +1,89 @@
+package openhue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// sendResourceRequest sends a request to an operation of the bridge that is missing from the API specification, such
+// as the creation or the deletion of some resources, and returns the identifiers of the affected resources.
+//
+// The request targets /clip/v2/resource/{rtype}, or /clip/v2/resource/{rtype}/{id} when id is not empty. The body is
+// sent as JSON, a nil body sends no body at all.
+func (h *Home) sendResourceRequest(ctx context.Context, method string, rtype ResourceIdentifierRtype, id string, body any) ([]ResourceIdentifier, error) {
+
+	if h.client == nil {
+		return nil, errors.New("no client to send the request")
+	}
+
+	serverURL, err := url.Parse(h.client.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := "./clip/v2/resource/" + url.PathEscape(string(rtype))
+	if id != "" {
+		operationPath += "/" + url.PathEscape(id)
+	}
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, queryURL.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, editor := range h.client.RequestEditors {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	rsp, err := h.client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data   []ResourceIdentifier `json:"data"`
+		Errors []Error              `json:"errors"`
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		apiErr := &ApiError{StatusCode: rsp.StatusCode, Status: rsp.Status}
+		if json.Unmarshal(data, &result) == nil {
+			apiErr.Description = extractErrorDescriptions(result.Errors)
+		}
+		return nil, apiErr
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
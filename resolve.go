@@ -0,0 +1,190 @@
+package openhue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// RESOURCE RESOLVER
+//--------------------------------------------------------------------------------------------------------------------//
+
+// resolver fetches the resources of a given type, either one by ID or all at once.
+type resolver struct {
+	byId func(h *Home, ctx context.Context, id string) (any, error)
+	all  func(h *Home, ctx context.Context) (map[string]any, error)
+}
+
+// resolvers registers how each resource type is fetched. Resolve returns pointers to the generated types,
+// for instance a ResourceIdentifier of type light resolves to a *LightGet.
+var resolvers = map[ResourceIdentifierRtype]resolver{
+	ResourceIdentifierRtypeBehaviorInstance:           newResolver((*Home).GetBehaviorInstanceById, (*Home).GetBehaviorInstances),
+	ResourceIdentifierRtypeBehaviorScript:             newResolver((*Home).GetBehaviorScriptById, (*Home).GetBehaviorScripts),
+	ResourceIdentifierRtypeBridge:                     {all: resolveAll((*Home).GetBridges)},
+	ResourceIdentifierRtypeBridgeHome:                 {byId: resolveById(getBridgeHomeById)},
+	ResourceIdentifierRtypeButton:                     newResolver((*Home).GetButtonById, (*Home).GetButtons),
+	ResourceIdentifierRtypeCameraMotion:               newResolver((*Home).GetCameraMotionSensorById, (*Home).GetCameraMotionSensors),
+	ResourceIdentifierRtypeContact:                    newResolver((*Home).GetContactSensorById, (*Home).GetContactSensors),
+	ResourceIdentifierRtypeDevice:                     newResolver((*Home).GetDeviceById, (*Home).GetDevices),
+	ResourceIdentifierRtypeDevicePower:                newResolver((*Home).GetDevicePowerById, (*Home).GetDevicePowers),
+	ResourceIdentifierRtypeEntertainmentConfiguration: newResolver((*Home).GetEntertainmentConfigurationById, (*Home).GetEntertainmentConfigurations),
+	ResourceIdentifierRtypeGeofenceClient:             newResolver((*Home).GetGeofenceClientById, (*Home).GetGeofenceClients),
+	ResourceIdentifierRtypeGeolocation:                newResolver((*Home).GetGeolocationById, (*Home).GetGeolocations),
+	ResourceIdentifierRtypeGroupedLight:               newResolver((*Home).GetGroupedLightById, (*Home).GetGroupedLights),
+	ResourceIdentifierRtypeGroupedLightLevel:          newResolver((*Home).GetGroupedLightLevelById, (*Home).GetGroupedLightLevels),
+	ResourceIdentifierRtypeGroupedMotion:              newResolver((*Home).GetGroupedMotionById, (*Home).GetGroupedMotions),
+	ResourceIdentifierRtypeHomekit:                    newResolver((*Home).GetHomekitById, (*Home).GetHomekits),
+	ResourceIdentifierRtypeLight:                      newResolver((*Home).GetLightById, (*Home).GetLights),
+	ResourceIdentifierRtypeLightLevel:                 newResolver((*Home).GetLightLevelSensorById, (*Home).GetLightLevelSensors),
+	ResourceIdentifierRtypeMatter:                     newResolver((*Home).GetMatterById, (*Home).GetMatters),
+	ResourceIdentifierRtypeMatterFabric:               newResolver((*Home).GetMatterFabricById, (*Home).GetMatterFabrics),
+	ResourceIdentifierRtypeMotion:                     newResolver((*Home).GetMotionSensorById, (*Home).GetMotionSensors),
+	ResourceIdentifierRtypeRelativeRotary:             newResolver((*Home).GetRelativeRotaryById, (*Home).GetRelativeRotaries),
+	ResourceIdentifierRtypeRoom:                       newResolver((*Home).GetRoomById, (*Home).GetRooms),
+	ResourceIdentifierRtypeScene:                      newResolver((*Home).GetSceneById, (*Home).GetScenes),
+	ResourceIdentifierRtypeSmartScene:                 newResolver((*Home).GetSmartSceneById, (*Home).GetSmartScenes),
+	ResourceIdentifierRtypeTamper:                     newResolver((*Home).GetTamperSensorById, (*Home).GetTamperSensors),
+	ResourceIdentifierRtypeTemperature:                newResolver((*Home).GetTemperatureSensorById, (*Home).GetTemperatureSensors),
+	ResourceIdentifierRtypeZigbeeConnectivity:         newResolver((*Home).GetZigbeeConnectivityById, (*Home).GetZigbeeConnectivities),
+	ResourceIdentifierRtypeZigbeeDeviceDiscovery:      newResolver((*Home).GetZigbeeDeviceDiscoveryById, (*Home).GetZigbeeDeviceDiscoveries),
+	ResourceIdentifierRtypeZone:                       newResolver((*Home).GetZoneById, (*Home).GetZones),
+}
+
+// Resolve fetches the resource a ResourceIdentifier points at. The concrete type of the returned value depends on
+// the resource type, for instance *LightGet for a light or *RoomGet for a room. Use ResolveAs to get a typed result.
+// It returns an error wrapping ErrUnsupportedResource if the resource type cannot be resolved.
+func (h *Home) Resolve(ctx context.Context, ref ResourceIdentifier) (any, error) {
+
+	if ref.Rid == nil || ref.Rtype == nil {
+		return nil, errors.New("illegal arguments, rid and rtype must be set")
+	}
+
+	r, ok := resolvers[*ref.Rtype]
+	if !ok {
+		return nil, fmt.Errorf("unable to resolve %s: %w", *ref.Rtype, ErrUnsupportedResource)
+	}
+
+	if r.byId != nil {
+		return r.byId(h, ctx, *ref.Rid)
+	}
+
+	resources, err := r.all(h, ctx)
+	if err != nil {
+		return nil, err
+	}
+	resource, ok := resources[*ref.Rid]
+	if !ok {
+		return nil, fmt.Errorf("%s %s: %w", *ref.Rtype, *ref.Rid, ErrNotFound)
+	}
+	return resource, nil
+}
+
+// ResolveAll fetches the resources the given ResourceIdentifiers point at, in the same order. Resources of the same
+// type are fetched at once, so that resolving many references only costs a single call per resource type.
+// References to resources that do not exist anymore resolve to nil.
+func (h *Home) ResolveAll(ctx context.Context, refs []ResourceIdentifier) ([]any, error) {
+
+	// distinct IDs per type, to fetch a single resource by ID rather than the whole list when possible
+	ids := make(map[ResourceIdentifierRtype]map[string]bool)
+	for _, ref := range refs {
+		if ref.Rid == nil || ref.Rtype == nil {
+			return nil, errors.New("illegal arguments, rid and rtype must be set")
+		}
+		if _, ok := resolvers[*ref.Rtype]; !ok {
+			return nil, fmt.Errorf("unable to resolve %s: %w", *ref.Rtype, ErrUnsupportedResource)
+		}
+		if ids[*ref.Rtype] == nil {
+			ids[*ref.Rtype] = make(map[string]bool)
+		}
+		ids[*ref.Rtype][*ref.Rid] = true
+	}
+
+	fetched := make(map[ResourceIdentifierRtype]map[string]any)
+	resolved := make([]any, len(refs))
+
+	for i, ref := range refs {
+		resources, ok := fetched[*ref.Rtype]
+		if !ok {
+			r := resolvers[*ref.Rtype]
+
+			var err error
+			if r.all == nil || (r.byId != nil && len(ids[*ref.Rtype]) == 1) {
+				resources, err = fetchOne(h, ctx, r, *ref.Rid)
+			} else {
+				resources, err = r.all(h, ctx)
+			}
+			if err != nil {
+				return nil, err
+			}
+			fetched[*ref.Rtype] = resources
+		}
+
+		resolved[i] = resources[*ref.Rid]
+	}
+
+	return resolved, nil
+}
+
+// ResolveAs fetches the resource a ResourceIdentifier points at and checks that it has the expected type.
+//
+// Example:
+//
+//	light, err := openhue.ResolveAs[openhue.LightGet](ctx, home, *scene.Actions[0].Target)
+func ResolveAs[T any](ctx context.Context, h *Home, ref ResourceIdentifier) (*T, error) {
+	resource, err := h.Resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	typed, ok := resource.(*T)
+	if !ok {
+		return nil, fmt.Errorf("%s %s resolves to %T, not %T", *ref.Rtype, *ref.Rid, resource, typed)
+	}
+	return typed, nil
+}
+
+func fetchOne(h *Home, ctx context.Context, r resolver, id string) (map[string]any, error) {
+	resource, err := r.byId(h, ctx, id)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrEmptyResponse) {
+		return map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{id: resource}, nil
+}
+
+func newResolver[T any](byId func(*Home, context.Context, string) (*T, error), all func(*Home, context.Context) (map[string]T, error)) resolver {
+	return resolver{byId: resolveById(byId), all: resolveAll(all)}
+}
+
+func resolveById[T any](byId func(*Home, context.Context, string) (*T, error)) func(*Home, context.Context, string) (any, error) {
+	return func(h *Home, ctx context.Context, id string) (any, error) {
+		return byId(h, ctx, id)
+	}
+}
+
+func resolveAll[T any](all func(*Home, context.Context) (map[string]T, error)) func(*Home, context.Context) (map[string]any, error) {
+	return func(h *Home, ctx context.Context) (map[string]any, error) {
+		resources, err := all(h, ctx)
+		if err != nil {
+			return nil, err
+		}
+		untyped := make(map[string]any, len(resources))
+		for id, r := range resources {
+			untyped[id] = &r
+		}
+		return untyped, nil
+	}
+}
+
+func getBridgeHomeById(h *Home, ctx context.Context, id string) (*BridgeHomeGet, error) {
+	home, err := h.GetBridgeHome(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if home.Id == nil || *home.Id != id {
+		return nil, fmt.Errorf("bridge_home %s: %w", id, ErrNotFound)
+	}
+	return home, nil
+}
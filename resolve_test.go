@@ -0,0 +1,58 @@
+package openhue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolve(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetRoomWithResponse", mock.Anything, "room-1", mock.Anything).Return(dataResponse[*GetRoomResponse](t, `[{"id": "room-1", "metadata": {"name": "Kitchen"}}]`), nil)
+
+	resource, err := home.Resolve(context.Background(), ResourceIdentifier{Rid: ptr("room-1"), Rtype: ptr(ResourceIdentifierRtypeRoom)})
+	assert.NoError(t, err)
+	assert.IsType(t, &RoomGet{}, resource)
+
+	room, err := ResolveAs[RoomGet](context.Background(), home, ResourceIdentifier{Rid: ptr("room-1"), Rtype: ptr(ResourceIdentifierRtypeRoom)})
+	assert.NoError(t, err)
+	assert.Equal(t, "Kitchen", *room.Metadata.Name)
+
+	_, err = ResolveAs[LightGet](context.Background(), home, ResourceIdentifier{Rid: ptr("room-1"), Rtype: ptr(ResourceIdentifierRtypeRoom)})
+	assert.ErrorContains(t, err, "resolves to *openhue.RoomGet")
+}
+
+func TestResolve_Unsupported(t *testing.T) {
+	home, _ := NewTestHome()
+
+	_, err := home.Resolve(context.Background(), ResourceIdentifier{Rid: ptr("x"), Rtype: ptr(ResourceIdentifierRtypeAuthV1)})
+	assert.True(t, errors.Is(err, ErrUnsupportedResource))
+}
+
+func TestResolveAll_SingleFetchPerType(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).
+		Return(dataResponse[*GetLightsResponse](t, `[
+			{"id": "light-1", "metadata": {"name": "Ceiling"}},
+			{"id": "light-2", "metadata": {"name": "Lamp"}}
+		]`), nil).Once()
+	m.On("GetRoomWithResponse", mock.Anything, "room-1", mock.Anything).Return(dataResponse[*GetRoomResponse](t, `[{"id": "room-1", "metadata": {"name": "Kitchen"}}]`), nil).Once()
+
+	resolved, err := home.ResolveAll(context.Background(), []ResourceIdentifier{
+		{Rid: ptr("light-2"), Rtype: ptr(ResourceIdentifierRtypeLight)},
+		{Rid: ptr("room-1"), Rtype: ptr(ResourceIdentifierRtypeRoom)},
+		{Rid: ptr("light-1"), Rtype: ptr(ResourceIdentifierRtypeLight)},
+		{Rid: ptr("deleted"), Rtype: ptr(ResourceIdentifierRtypeLight)},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resolved, 4)
+	assert.Equal(t, "Lamp", *resolved[0].(*LightGet).Metadata.Name)
+	assert.Equal(t, "Ceiling", *resolved[2].(*LightGet).Metadata.Name)
+	assert.Nil(t, resolved[3])
+	m.AssertNumberOfCalls(t, "GetLightsWithResponse", 1)
+}
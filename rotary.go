@@ -0,0 +1,375 @@
+package openhue
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// ROTARY CONTROLLER
+//--------------------------------------------------------------------------------------------------------------------//
+
+// RotaryEvent is a rotation of a relative rotary, such as the dial of a Hue tap dial switch.
+type RotaryEvent struct {
+	// Start is true for the first event of a rotation, false for the events repeated while the rotation goes on.
+	Start     bool
+	Clockwise bool
+	// Steps is the amount of rotation since the previous event.
+	Steps int
+	// Duration is the duration of the rotation since the previous event.
+	Duration time.Duration
+	Updated  time.Time
+}
+
+// LastRotation returns the last rotation reported by the rotary, false if it has never been rotated.
+func (r *RelativeRotaryGet) LastRotation() (RotaryEvent, bool) {
+	if r.RelativeRotary == nil || r.RelativeRotary.RotaryReport == nil || r.RelativeRotary.RotaryReport.Rotation == nil {
+		return RotaryEvent{}, false
+	}
+	report := r.RelativeRotary.RotaryReport
+	event := RotaryEvent{
+		Start: report.Action != nil && *report.Action == RelativeRotaryGetRelativeRotaryRotaryReportActionStart,
+		Clockwise: report.Rotation.Direction != nil &&
+			*report.Rotation.Direction == RelativeRotaryGetRelativeRotaryRotaryReportRotationDirectionClockWise,
+	}
+	if report.Rotation.Steps != nil {
+		event.Steps = *report.Rotation.Steps
+	}
+	if report.Rotation.Duration != nil {
+		event.Duration = time.Duration(*report.Rotation.Duration) * time.Millisecond
+	}
+	if report.Updated != nil {
+		event.Updated = *report.Updated
+	}
+	return event, true
+}
+
+// RotaryMode is the property of the target a RotaryController adjusts.
+type RotaryMode int
+
+const (
+	// RotaryBrightness adjusts the brightness, in percent. Clockwise rotations increase it.
+	RotaryBrightness RotaryMode = iota
+	// RotaryColorTemperature adjusts the color temperature, in mirek. Clockwise rotations increase it, that is make
+	// the light warmer.
+	RotaryColorTemperature
+)
+
+type rotaryConfig struct {
+	mode         RotaryMode
+	stepSize     float64
+	acceleration float64
+	min, max     float64
+	bounded      bool
+	inverted     bool
+	interval     time.Duration
+}
+
+// RotaryOption is a functional option for configuring a RotaryController.
+type RotaryOption func(*rotaryConfig)
+
+// WithRotaryMode sets the property adjusted by the controller. It defaults to RotaryBrightness.
+func WithRotaryMode(mode RotaryMode) RotaryOption {
+	return func(c *rotaryConfig) {
+		c.mode = mode
+	}
+}
+
+// WithRotaryStepSize sets the adjustment of a single rotation step, in percent of brightness or in mirek. It
+// defaults to 1% of brightness and 5 mirek. Values lower than or equal to 0 are ignored.
+func WithRotaryStepSize(size float64) RotaryOption {
+	return func(c *rotaryConfig) {
+		if size > 0 {
+			c.stepSize = size
+		}
+	}
+}
+
+// WithRotaryAcceleration makes fast rotations cover a wider range: each step is multiplied by
+// 1 + acceleration × steps per second / 10. It defaults to 0, in which case the adjustment is linear.
+// Negative values are ignored.
+func WithRotaryAcceleration(acceleration float64) RotaryOption {
+	return func(c *rotaryConfig) {
+		if acceleration >= 0 {
+			c.acceleration = acceleration
+		}
+	}
+}
+
+// WithRotaryBounds restricts the adjusted value to the given range, in percent of brightness or in mirek. It
+// defaults to 1–100% of brightness, and to the range supported by the light for the color temperature.
+func WithRotaryBounds(minimum, maximum float64) RotaryOption {
+	return func(c *rotaryConfig) {
+		if minimum <= maximum {
+			c.min, c.max, c.bounded = minimum, maximum, true
+		}
+	}
+}
+
+// WithRotaryInverted makes counterclockwise rotations increase the value.
+func WithRotaryInverted() RotaryOption {
+	return func(c *rotaryConfig) {
+		c.inverted = true
+	}
+}
+
+// WithRotaryInterval sets how often Run polls the rotary and sends the accumulated adjustment. It defaults to
+// 200 milliseconds. Values lower than or equal to 0 are ignored.
+func WithRotaryInterval(d time.Duration) RotaryOption {
+	return func(c *rotaryConfig) {
+		if d > 0 {
+			c.interval = d
+		}
+	}
+}
+
+// RotaryController turns rotary events into brightness or color temperature adjustments of a light or a grouped
+// light. Events are accumulated and sent as a single DimmingDelta or ColorTemperatureDelta command on Flush, so that
+// rapid ticks do not flood the bridge. The controller keeps track of the absolute value of the target to enforce its
+// bounds, and resynchronizes it at the start of each rotation.
+//
+// Grouped lights do not report their color temperature: the bounds are not enforced when adjusting it, the bridge
+// clipping the value to the range supported by each light.
+type RotaryController struct {
+	home   *Home
+	target ResourceIdentifier
+	cfg    rotaryConfig
+
+	// flushMu serializes the flushes, mu only guards the fields below so that Handle never waits for the bridge.
+	flushMu sync.Mutex
+	mu      sync.Mutex
+	pending float64
+	// current is the last known value of the target, nil when it must be read again.
+	current *float64
+	// rotations counts the rotations started, a value read before the start of the current rotation is discarded.
+	rotations int
+}
+
+// NewRotaryController returns a controller adjusting the given light or grouped light.
+//
+// Example:
+//
+//	rtype := openhue.ResourceIdentifierRtypeGroupedLight
+//	controller, err := home.NewRotaryController(openhue.ResourceIdentifier{Rid: &groupedLightId, Rtype: &rtype},
+//		openhue.WithRotaryAcceleration(1),
+//		openhue.WithRotaryBounds(10, 100))
+//	err = controller.Run(ctx, rotaryId)
+func (h *Home) NewRotaryController(target ResourceIdentifier, opts ...RotaryOption) (*RotaryController, error) {
+
+	if target.Rid == nil || target.Rtype == nil ||
+		(*target.Rtype != ResourceIdentifierRtypeLight && *target.Rtype != ResourceIdentifierRtypeGroupedLight) {
+		return nil, fmt.Errorf("rotary target must be a light or a grouped light: %w", ErrUnsupportedResource)
+	}
+
+	cfg := rotaryConfig{interval: 200 * time.Millisecond}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.stepSize == 0 {
+		cfg.stepSize = 1
+		if cfg.mode == RotaryColorTemperature {
+			cfg.stepSize = 5
+		}
+	}
+	if !cfg.bounded && cfg.mode == RotaryBrightness {
+		cfg.min, cfg.max, cfg.bounded = 1, 100, true
+	}
+
+	return &RotaryController{home: h, target: target, cfg: cfg}, nil
+}
+
+// Handle accumulates a rotary event. The adjustment is only sent to the bridge on the next Flush.
+func (c *RotaryController) Handle(event RotaryEvent) {
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if event.Start {
+		c.current = nil
+		c.rotations++
+	}
+
+	delta := float64(event.Steps) * c.cfg.stepSize
+	if c.cfg.acceleration > 0 && event.Duration > 0 {
+		stepsPerSecond := float64(event.Steps) / event.Duration.Seconds()
+		delta *= 1 + c.cfg.acceleration*stepsPerSecond/10
+	}
+	if event.Clockwise == c.cfg.inverted {
+		delta = -delta
+	}
+	c.pending += delta
+}
+
+// Flush sends the adjustment accumulated since the previous Flush as a single command, clamped to the bounds of the
+// controller. It does nothing if there is no adjustment to send.
+func (c *RotaryController) Flush(ctx context.Context) error {
+
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+
+	c.mu.Lock()
+	delta, current, rotations := c.pending, c.current, c.rotations
+	c.pending = 0
+	c.mu.Unlock()
+
+	if delta == 0 {
+		return nil
+	}
+
+	if current == nil {
+		var minimum, maximum float64
+		var err error
+		current, minimum, maximum, err = c.read(ctx)
+		if err != nil {
+			// the adjustment is sent on the next Flush
+			c.mu.Lock()
+			c.pending += delta
+			c.mu.Unlock()
+			return err
+		}
+		if !c.cfg.bounded && current != nil {
+			c.cfg.min, c.cfg.max, c.cfg.bounded = minimum, maximum, true
+		}
+	}
+	if current != nil && c.cfg.bounded {
+		target := math.Max(c.cfg.min, math.Min(c.cfg.max, *current+delta))
+		delta = target - *current
+	}
+	if c.cfg.mode == RotaryColorTemperature {
+		delta = math.Round(delta)
+	}
+
+	if delta != 0 {
+		if err := c.send(ctx, delta); err != nil {
+			// the actual value of the target is unknown
+			c.setCurrent(nil, rotations)
+			return err
+		}
+		if current != nil {
+			value := *current + delta
+			current = &value
+		}
+	}
+
+	c.setCurrent(current, rotations)
+	return nil
+}
+
+// setCurrent records the value of the target, unless a new rotation has started since it was read.
+func (c *RotaryController) setCurrent(current *float64, rotations int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rotations == rotations {
+		c.current = current
+	}
+}
+
+// Run polls the rotary of the given ID, handles its new rotations and flushes the adjustments, until the context is
+// done. Polling only sees the last rotation reported by the rotary: when the events are available from another
+// source, call Handle and Flush directly instead.
+func (c *RotaryController) Run(ctx context.Context, relativeRotaryId string) error {
+
+	rotary, err := c.home.GetRelativeRotaryById(ctx, relativeRotaryId)
+	if err != nil {
+		return err
+	}
+	last, _ := rotary.LastRotation()
+
+	ticker := time.NewTicker(c.cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		rotary, err := c.home.GetRelativeRotaryById(ctx, relativeRotaryId)
+		if err != nil {
+			return err
+		}
+		if event, ok := rotary.LastRotation(); ok && event.Updated.After(last.Updated) {
+			c.Handle(event)
+			last = event
+		}
+
+		if err := c.Flush(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// read returns the current value of the target, nil if unknown, and the bounds it supports.
+func (c *RotaryController) read(ctx context.Context) (*float64, float64, float64, error) {
+
+	if *c.target.Rtype == ResourceIdentifierRtypeGroupedLight {
+		if c.cfg.mode == RotaryColorTemperature {
+			return nil, 0, 0, nil
+		}
+		group, err := c.home.GetGroupedLightById(ctx, *c.target.Rid)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if group.Dimming == nil || group.Dimming.Brightness == nil {
+			return nil, 0, 0, nil
+		}
+		brightness := float64(*group.Dimming.Brightness)
+		return &brightness, 0, 100, nil
+	}
+
+	light, err := c.home.GetLightById(ctx, *c.target.Rid)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	if c.cfg.mode == RotaryBrightness {
+		if light.Dimming == nil || light.Dimming.Brightness == nil {
+			return nil, 0, 0, nil
+		}
+		brightness := float64(*light.Dimming.Brightness)
+		return &brightness, 0, 100, nil
+	}
+
+	ct := light.ColorTemperature
+	if ct == nil || ct.Mirek == nil || (ct.MirekValid != nil && !*ct.MirekValid) {
+		return nil, 0, 0, nil
+	}
+	mirek := float64(*ct.Mirek)
+	minimum, maximum := 153.0, 500.0
+	if ct.MirekSchema != nil && ct.MirekSchema.MirekMinimum != nil && ct.MirekSchema.MirekMaximum != nil {
+		minimum, maximum = float64(*ct.MirekSchema.MirekMinimum), float64(*ct.MirekSchema.MirekMaximum)
+	}
+	return &mirek, minimum, maximum, nil
+}
+
+// send applies a relative adjustment to the target.
+func (c *RotaryController) send(ctx context.Context, delta float64) error {
+
+	var dimming *DimmingDelta
+	var ct *ColorTemperatureDelta
+
+	if c.cfg.mode == RotaryBrightness {
+		action := DimmingDeltaActionUp
+		if delta < 0 {
+			action = DimmingDeltaActionDown
+		}
+		brightness := float32(math.Abs(delta))
+		dimming = &DimmingDelta{Action: &action, BrightnessDelta: &brightness}
+	} else {
+		action := ColorTemperatureDeltaActionUp
+		if delta < 0 {
+			action = ColorTemperatureDeltaActionDown
+		}
+		mirek := int(math.Abs(delta))
+		ct = &ColorTemperatureDelta{Action: &action, MirekDelta: &mirek}
+	}
+
+	if *c.target.Rtype == ResourceIdentifierRtypeGroupedLight {
+		return c.home.UpdateGroupedLight(ctx, *c.target.Rid, GroupedLightPut{DimmingDelta: dimming, ColorTemperatureDelta: ct})
+	}
+	return c.home.UpdateLight(ctx, *c.target.Rid, LightPut{DimmingDelta: dimming, ColorTemperatureDelta: ct})
+}
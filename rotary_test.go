@@ -0,0 +1,193 @@
+package openhue
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func groupedLightTarget() ResourceIdentifier {
+	return ResourceIdentifier{Rid: ptr("gl-1"), Rtype: ptr(ResourceIdentifierRtypeGroupedLight)}
+}
+
+func mockGroupedLight(t *testing.T, m *ClientWithResponsesMock, brightness float64) {
+	m.On("GetGroupedLightWithResponse", mock.Anything, "gl-1", mock.Anything).Return(dataResponse[*GetGroupedLightResponse](t, `[{"id": "gl-1", "dimming": {"brightness": `+formatValue(brightness)+`}}]`), nil)
+	m.On("UpdateGroupedLightWithResponse", mock.Anything, "gl-1", mock.Anything, mock.Anything).Return(&UpdateGroupedLightResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+}
+
+func relativeRotaryResponse(t *testing.T, report string) *GetRelativeRotaryResponse {
+	return dataResponse[*GetRelativeRotaryResponse](t, `[{"id": "rr-1", "relative_rotary": {"rotary_report": `+report+`}}]`)
+}
+
+func dimmingDelta(action DimmingDeltaAction, brightness float32) any {
+	return mock.MatchedBy(func(body GroupedLightPut) bool {
+		return body.DimmingDelta != nil && *body.DimmingDelta.Action == action && *body.DimmingDelta.BrightnessDelta == brightness
+	})
+}
+
+func TestRelativeRotaryGet_LastRotation(t *testing.T) {
+	rotary := fromJSON[RelativeRotaryGet](t, `{"relative_rotary": {"rotary_report": {
+		"action": "start", "rotation": {"direction": "counter_clock_wise", "steps": 30, "duration": 400},
+		"updated": "2024-01-01T10:00:00Z"
+	}}}`)
+
+	event, ok := rotary.LastRotation()
+	assert.True(t, ok)
+	assert.Equal(t, RotaryEvent{
+		Start:    true,
+		Steps:    30,
+		Duration: 400 * time.Millisecond,
+		Updated:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	}, event)
+
+	_, ok = (&RelativeRotaryGet{}).LastRotation()
+	assert.False(t, ok)
+}
+
+func TestRotaryController_Coalesce(t *testing.T) {
+	home, m := NewTestHome()
+	mockGroupedLight(t, m, 50)
+
+	controller, err := home.NewRotaryController(groupedLightTarget(), WithRotaryStepSize(2))
+	assert.NoError(t, err)
+
+	controller.Handle(RotaryEvent{Start: true, Clockwise: true, Steps: 3})
+	controller.Handle(RotaryEvent{Clockwise: true, Steps: 5})
+	controller.Handle(RotaryEvent{Clockwise: false, Steps: 1})
+	assert.NoError(t, controller.Flush(context.Background()))
+	assert.NoError(t, controller.Flush(context.Background()))
+
+	m.AssertNumberOfCalls(t, "UpdateGroupedLightWithResponse", 1)
+	m.AssertCalled(t, "UpdateGroupedLightWithResponse", mock.Anything, "gl-1", dimmingDelta(DimmingDeltaActionUp, 14), mock.Anything)
+}
+
+func TestRotaryController_Bounds(t *testing.T) {
+	home, m := NewTestHome()
+	mockGroupedLight(t, m, 25)
+
+	controller, err := home.NewRotaryController(groupedLightTarget(), WithRotaryBounds(20, 80))
+	assert.NoError(t, err)
+
+	controller.Handle(RotaryEvent{Start: true, Clockwise: false, Steps: 10})
+	assert.NoError(t, controller.Flush(context.Background()))
+
+	// already at the lower bound
+	controller.Handle(RotaryEvent{Clockwise: false, Steps: 10})
+	assert.NoError(t, controller.Flush(context.Background()))
+
+	controller.Handle(RotaryEvent{Clockwise: true, Steps: 100})
+	assert.NoError(t, controller.Flush(context.Background()))
+
+	m.AssertNumberOfCalls(t, "GetGroupedLightWithResponse", 1)
+	m.AssertNumberOfCalls(t, "UpdateGroupedLightWithResponse", 2)
+	m.AssertCalled(t, "UpdateGroupedLightWithResponse", mock.Anything, "gl-1", dimmingDelta(DimmingDeltaActionDown, 5), mock.Anything)
+	m.AssertCalled(t, "UpdateGroupedLightWithResponse", mock.Anything, "gl-1", dimmingDelta(DimmingDeltaActionUp, 60), mock.Anything)
+}
+
+func TestRotaryController_HandleDuringFlush(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetGroupedLightWithResponse", mock.Anything, "gl-1", mock.Anything).Return(dataResponse[*GetGroupedLightResponse](t, `[{"id": "gl-1", "dimming": {"brightness": 50}}]`), nil)
+
+	sending, release := make(chan struct{}), make(chan struct{})
+	m.On("UpdateGroupedLightWithResponse", mock.Anything, "gl-1", mock.Anything, mock.Anything).Return(&UpdateGroupedLightResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil).Run(func(mock.Arguments) {
+		sending <- struct{}{}
+		<-release
+	})
+
+	controller, err := home.NewRotaryController(groupedLightTarget())
+	assert.NoError(t, err)
+
+	controller.Handle(RotaryEvent{Start: true, Clockwise: true, Steps: 10})
+	flushed := make(chan error)
+	go func() {
+		flushed <- controller.Flush(context.Background())
+	}()
+	<-sending
+
+	handled := make(chan struct{})
+	go func() {
+		controller.Handle(RotaryEvent{Clockwise: true, Steps: 5})
+		close(handled)
+	}()
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("Handle blocked while the adjustment was being sent")
+	}
+
+	close(release)
+	assert.NoError(t, <-flushed)
+
+	// the events handled during the flush are sent on the next one, from the value known after the first one
+	go func() { <-sending }()
+	assert.NoError(t, controller.Flush(context.Background()))
+	m.AssertNumberOfCalls(t, "GetGroupedLightWithResponse", 1)
+	m.AssertCalled(t, "UpdateGroupedLightWithResponse", mock.Anything, "gl-1", dimmingDelta(DimmingDeltaActionUp, 10), mock.Anything)
+	m.AssertCalled(t, "UpdateGroupedLightWithResponse", mock.Anything, "gl-1", dimmingDelta(DimmingDeltaActionUp, 5), mock.Anything)
+}
+
+func TestRotaryController_ColorTemperature(t *testing.T) {
+	home, m := NewTestHome()
+
+	light := dataResponse[*GetLightResponse](t, `[{"id": "light-1", "color_temperature": {
+		"mirek": 400, "mirek_valid": true, "mirek_schema": {"mirek_minimum": 153, "mirek_maximum": 454}
+	}}]`)
+	m.On("GetLightWithResponse", mock.Anything, "light-1", mock.Anything).Return(light, nil)
+	m.On("UpdateLightWithResponse", mock.Anything, "light-1", mock.Anything, mock.Anything).Return(&UpdateLightResponse{
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	controller, err := home.NewRotaryController(
+		ResourceIdentifier{Rid: ptr("light-1"), Rtype: ptr(ResourceIdentifierRtypeLight)},
+		WithRotaryMode(RotaryColorTemperature),
+		WithRotaryAcceleration(1),
+	)
+	assert.NoError(t, err)
+
+	// 10 steps in 500ms: 20 steps per second, that is a ×3 acceleration
+	controller.Handle(RotaryEvent{Start: true, Clockwise: true, Steps: 10, Duration: 500 * time.Millisecond})
+	assert.NoError(t, controller.Flush(context.Background()))
+
+	m.AssertCalled(t, "UpdateLightWithResponse", mock.Anything, "light-1", mock.MatchedBy(func(body LightPut) bool {
+		return body.DimmingDelta == nil &&
+			*body.ColorTemperatureDelta.Action == ColorTemperatureDeltaActionUp && *body.ColorTemperatureDelta.MirekDelta == 54
+	}), mock.Anything)
+}
+
+func TestRotaryController_UnsupportedTarget(t *testing.T) {
+	home, _ := NewTestHome()
+
+	_, err := home.NewRotaryController(ResourceIdentifier{Rid: ptr("room-1"), Rtype: ptr(ResourceIdentifierRtypeRoom)})
+	assert.ErrorIs(t, err, ErrUnsupportedResource)
+}
+
+func TestRotaryController_Run(t *testing.T) {
+	home, m := NewTestHome()
+	mockGroupedLight(t, m, 50)
+
+	previous := `{"action": "repeat", "rotation": {"direction": "clock_wise", "steps": 8, "duration": 400}, "updated": "2024-01-01T10:00:00Z"}`
+	next := `{"action": "start", "rotation": {"direction": "clock_wise", "steps": 4, "duration": 400}, "updated": "2024-01-01T10:05:00Z"}`
+	m.On("GetRelativeRotaryWithResponse", mock.Anything, "rr-1", mock.Anything).Return(relativeRotaryResponse(t, previous), nil).Twice()
+	m.On("GetRelativeRotaryWithResponse", mock.Anything, "rr-1", mock.Anything).Return(relativeRotaryResponse(t, next), nil)
+
+	controller, err := home.NewRotaryController(groupedLightTarget(), WithRotaryInterval(time.Millisecond))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = controller.Run(ctx, "rr-1")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	m.AssertNumberOfCalls(t, "UpdateGroupedLightWithResponse", 1)
+	m.AssertCalled(t, "UpdateGroupedLightWithResponse", mock.Anything, "gl-1", dimmingDelta(DimmingDeltaActionUp, 4), mock.Anything)
+}
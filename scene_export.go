@@ -0,0 +1,477 @@
+package openhue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SCENE EXPORT / IMPORT
+//--------------------------------------------------------------------------------------------------------------------//
+
+// SceneDocument is a portable representation of a set of scenes. Rooms, zones and lights are referenced by name
+// instead of by ID, so that the same document can be imported on any bridge and kept under version control.
+type SceneDocument struct {
+	Scenes []PortableScene `json:"scenes"`
+}
+
+// PortableScene is a scene whose group and lights are referenced by name.
+type PortableScene struct {
+	Name        string           `json:"name"`
+	Group       PortableGroupRef `json:"group"`
+	Actions     []PortableAction `json:"actions"`
+	Palette     *ScenePalette    `json:"palette,omitempty"`
+	Speed       *float32         `json:"speed,omitempty"`
+	AutoDynamic *bool            `json:"auto_dynamic,omitempty"`
+}
+
+// PortableGroupRef references a room or a zone by name.
+type PortableGroupRef struct {
+	Name string                  `json:"name"`
+	Type ResourceIdentifierRtype `json:"type"`
+}
+
+// PortableAction is the state a light, referenced by name, takes when the scene is recalled.
+type PortableAction struct {
+	Light      string            `json:"light"`
+	On         *bool             `json:"on,omitempty"`
+	Brightness *Brightness       `json:"brightness,omitempty"`
+	Mirek      *Mirek            `json:"mirek,omitempty"`
+	Color      *GamutPosition    `json:"color,omitempty"`
+	Gradient   *Gradient         `json:"gradient,omitempty"`
+	Effect     *SupportedEffects `json:"effect,omitempty"`
+}
+
+// SceneImportReport describes the outcome of ImportScenes.
+type SceneImportReport struct {
+	// Created contains the names of the scenes that have been created on the bridge.
+	Created []string
+	// Updated contains the names of the scenes that already existed in their group and have been updated.
+	Updated []string
+	// Skipped contains the names of the scenes that could not be imported because their group or all of their
+	// lights could not be resolved.
+	Skipped []string
+	// Unmatched lists every name of the document that could not be resolved to a resource on the bridge.
+	Unmatched []UnmatchedReference
+}
+
+// UnmatchedReference is a name of a SceneDocument that could not be resolved on the target bridge.
+type UnmatchedReference struct {
+	Scene  string
+	Type   ResourceIdentifierRtype
+	Name   string
+	Reason string
+}
+
+func (u UnmatchedReference) String() string {
+	return fmt.Sprintf("scene \"%s\": %s \"%s\" %s", u.Scene, u.Type, u.Name, u.Reason)
+}
+
+// ParseSceneDocument decodes a SceneDocument from either its JSON or its YAML representation.
+func ParseSceneDocument(data []byte) (*SceneDocument, error) {
+
+	doc := &SceneDocument{}
+	if err := unmarshalYAML(data, doc); err != nil {
+		return nil, fmt.Errorf("unable to parse scene document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// unmarshalYAML decodes either JSON or YAML into v. YAML being a superset of JSON, both formats are decoded as YAML
+// then mapped through the JSON field names.
+func unmarshalYAML(data []byte, v any) error {
+
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	j, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(j, v)
+}
+
+// JSON encodes the document as indented JSON.
+func (d *SceneDocument) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML encodes the document as YAML, using the same field names as the JSON representation.
+func (d *SceneDocument) YAML() ([]byte, error) {
+
+	j, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw interface{}
+	if err := yaml.Unmarshal(j, &raw); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(raw)
+}
+
+// ExportScenes exports all the scenes of the bridge into a portable SceneDocument, sorted by group name then by scene
+// name so that exporting the same bridge twice gives the same document.
+// Scenes whose group no longer exists are ignored, as well as actions targeting unknown lights.
+func (h *Home) ExportScenes(ctx context.Context) (*SceneDocument, error) {
+
+	scenes, err := h.GetScenes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := h.newNameIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &SceneDocument{Scenes: make([]PortableScene, 0, len(scenes))}
+	for _, id := range sortedIds(scenes) {
+		scene := scenes[id]
+		if scene.Group == nil || scene.Group.Rid == nil || scene.Group.Rtype == nil {
+			continue
+		}
+
+		groupName, ok := idx.groupNames[*scene.Group.Rid]
+		if !ok {
+			continue
+		}
+
+		ps := PortableScene{
+			Group:       PortableGroupRef{Name: groupName, Type: *scene.Group.Rtype},
+			Palette:     scene.Palette,
+			Speed:       scene.Speed,
+			AutoDynamic: scene.AutoDynamic,
+		}
+		if scene.Metadata != nil && scene.Metadata.Name != nil {
+			ps.Name = *scene.Metadata.Name
+		}
+
+		if scene.Actions != nil {
+			for _, a := range *scene.Actions {
+				if a.Target == nil || a.Target.Rid == nil {
+					continue
+				}
+				lightName, ok := idx.lightNames[*a.Target.Rid]
+				if !ok {
+					continue
+				}
+				ps.Actions = append(ps.Actions, newPortableAction(lightName, a))
+			}
+		}
+
+		doc.Scenes = append(doc.Scenes, ps)
+	}
+
+	// scenes are visited by ID, which breaks the ties between scenes of the same name
+	sort.SliceStable(doc.Scenes, func(i, j int) bool {
+		a, b := doc.Scenes[i], doc.Scenes[j]
+		if a.Group.Name != b.Group.Name {
+			return a.Group.Name < b.Group.Name
+		}
+		if a.Group.Type != b.Group.Type {
+			return a.Group.Type < b.Group.Type
+		}
+		return a.Name < b.Name
+	})
+
+	return doc, nil
+}
+
+// ImportScenes creates the scenes of the document on the bridge, resolving rooms, zones and lights by name.
+// A scene with the same name that already exists in the same group is updated instead of being duplicated.
+// Names that cannot be resolved do not fail the import, they are listed in the returned SceneImportReport.
+func (h *Home) ImportScenes(ctx context.Context, doc *SceneDocument) (*SceneImportReport, error) {
+
+	if doc == nil {
+		return nil, errors.New("illegal arguments, scene document must be set")
+	}
+
+	scenes, err := h.GetScenes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := h.newNameIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SceneImportReport{}
+
+	for _, ps := range doc.Scenes {
+
+		groupId, reason := idx.resolveGroup(ps.Group)
+		if reason != "" {
+			report.Skipped = append(report.Skipped, ps.Name)
+			report.Unmatched = append(report.Unmatched, UnmatchedReference{
+				Scene: ps.Name, Type: ps.Group.Type, Name: ps.Group.Name, Reason: reason,
+			})
+			continue
+		}
+
+		actions := make([]ActionPost, 0, len(ps.Actions))
+		for _, pa := range ps.Actions {
+			lightId, reason := idx.resolveLight(groupId, pa.Light)
+			if reason != "" {
+				report.Unmatched = append(report.Unmatched, UnmatchedReference{
+					Scene: ps.Name, Type: ResourceIdentifierRtypeLight, Name: pa.Light, Reason: reason,
+				})
+				continue
+			}
+			actions = append(actions, pa.actionPost(lightId))
+		}
+
+		if len(actions) == 0 {
+			report.Skipped = append(report.Skipped, ps.Name)
+			continue
+		}
+
+		name := ps.Name
+		if existingId := findSceneId(scenes, groupId, name); existingId != "" {
+			err = h.UpdateScene(ctx, existingId, ScenePut{
+				Actions:     &actions,
+				Metadata:    &SceneMetadata{Name: &name},
+				Palette:     ps.Palette,
+				Speed:       ps.Speed,
+				AutoDynamic: ps.AutoDynamic,
+			})
+			if err != nil {
+				return report, fmt.Errorf("unable to update scene \"%s\": %w", name, err)
+			}
+			report.Updated = append(report.Updated, name)
+			continue
+		}
+
+		groupType := ps.Group.Type
+		sceneType := ScenePostTypeScene
+		created, err := h.CreateScene(ctx, ScenePost{
+			Actions:     actions,
+			Group:       ResourceIdentifier{Rid: &groupId, Rtype: &groupType},
+			Metadata:    SceneMetadata{Name: &name},
+			Palette:     ps.Palette,
+			Speed:       ps.Speed,
+			AutoDynamic: ps.AutoDynamic,
+			Type:        &sceneType,
+		})
+		if err != nil {
+			return report, fmt.Errorf("unable to create scene \"%s\": %w", name, err)
+		}
+		report.Created = append(report.Created, name)
+
+		// a scene of the same name later in the document updates this one
+		if created.Rid != nil {
+			scenes[*created.Rid] = SceneGet{
+				Id:       created.Rid,
+				Group:    &ResourceIdentifier{Rid: &groupId, Rtype: &groupType},
+				Metadata: &SceneMetadata{Name: &name},
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func newPortableAction(lightName string, a ActionGet) PortableAction {
+
+	pa := PortableAction{Light: lightName}
+	if a.Action == nil {
+		return pa
+	}
+
+	if a.Action.On != nil {
+		pa.On = a.Action.On.On
+	}
+	if a.Action.Dimming != nil {
+		pa.Brightness = a.Action.Dimming.Brightness
+	}
+	if a.Action.ColorTemperature != nil {
+		pa.Mirek = a.Action.ColorTemperature.Mirek
+	}
+	if a.Action.Color != nil {
+		pa.Color = a.Action.Color.Xy
+	}
+	if a.Action.Effects != nil {
+		pa.Effect = a.Action.Effects.Effect
+	}
+	pa.Gradient = a.Action.Gradient
+
+	return pa
+}
+
+func (pa *PortableAction) actionPost(lightId string) ActionPost {
+
+	lightType := ResourceIdentifierRtypeLight
+	action := ActionPost{
+		Target: ResourceIdentifier{Rid: &lightId, Rtype: &lightType},
+	}
+
+	if pa.On != nil {
+		action.Action.On = &On{On: pa.On}
+	}
+	if pa.Brightness != nil {
+		action.Action.Dimming = &Dimming{Brightness: pa.Brightness}
+	}
+	if pa.Mirek != nil {
+		action.Action.ColorTemperature = &struct {
+			Mirek *Mirek `json:"mirek,omitempty"`
+		}{Mirek: pa.Mirek}
+	}
+	if pa.Color != nil {
+		action.Action.Color = &Color{Xy: pa.Color}
+	}
+	if pa.Effect != nil {
+		action.Action.Effects = &struct {
+			Effect *SupportedEffects `json:"effect,omitempty"`
+		}{Effect: pa.Effect}
+	}
+	action.Action.Gradient = pa.Gradient
+
+	return action
+}
+
+// findSceneId returns the ID of the scene of the given name in the room or zone, the lowest ID when several scenes
+// share that name.
+func findSceneId(scenes map[string]SceneGet, groupId, name string) string {
+	for _, id := range sortedIds(scenes) {
+		scene := scenes[id]
+		if scene.Group == nil || scene.Group.Rid == nil || *scene.Group.Rid != groupId {
+			continue
+		}
+		if scene.Metadata != nil && scene.Metadata.Name != nil && *scene.Metadata.Name == name {
+			return id
+		}
+	}
+	return ""
+}
+
+// nameIndex maps the IDs of rooms, zones and lights to their human-readable names, and back.
+type nameIndex struct {
+	groupNames map[string]string
+	groupTypes map[string]ResourceIdentifierRtype
+	lightNames map[string]string
+	// groupLights contains the IDs of the lights of each room or zone, either as children of the zone or as services
+	// of the devices of the room.
+	groupLights map[string]map[string]bool
+}
+
+func (h *Home) newNameIndex(ctx context.Context) (*nameIndex, error) {
+
+	rooms, err := h.GetRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := h.GetZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	lights, err := h.GetLights(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newNameIndexFrom(rooms, zones, lights), nil
+}
+
+func newNameIndexFrom(rooms map[string]RoomGet, zones map[string]RoomGet, lights map[string]LightGet) *nameIndex {
+
+	idx := &nameIndex{
+		groupNames:  make(map[string]string),
+		groupTypes:  make(map[string]ResourceIdentifierRtype),
+		lightNames:  make(map[string]string),
+		groupLights: make(map[string]map[string]bool),
+	}
+
+	for id, room := range rooms {
+		if room.Metadata != nil && room.Metadata.Name != nil {
+			idx.groupNames[id] = *room.Metadata.Name
+			idx.groupTypes[id] = ResourceIdentifierRtypeRoom
+		}
+	}
+	for id, zone := range zones {
+		if zone.Metadata != nil && zone.Metadata.Name != nil {
+			idx.groupNames[id] = *zone.Metadata.Name
+			idx.groupTypes[id] = ResourceIdentifierRtypeZone
+		}
+	}
+	for id, light := range lights {
+		if light.Metadata != nil && light.Metadata.Name != nil {
+			idx.lightNames[id] = *light.Metadata.Name
+		}
+	}
+
+	for _, groups := range []map[string]RoomGet{rooms, zones} {
+		for groupId, group := range groups {
+			children := make(map[string]bool)
+			for _, rtype := range []ResourceIdentifierRtype{ResourceIdentifierRtypeDevice, ResourceIdentifierRtypeLight} {
+				for _, child := range groupChildren(group, rtype) {
+					children[child] = true
+				}
+			}
+			members := make(map[string]bool)
+			for id, light := range lights {
+				if children[id] || (light.Owner != nil && light.Owner.Rid != nil && children[*light.Owner.Rid]) {
+					members[id] = true
+				}
+			}
+			idx.groupLights[groupId] = members
+		}
+	}
+
+	return idx
+}
+
+// resolveGroup returns the ID of the room or zone matching the reference, or the reason why it cannot be resolved.
+func (idx *nameIndex) resolveGroup(ref PortableGroupRef) (string, string) {
+	var candidates []string
+	for id, name := range idx.groupNames {
+		if idx.groupTypes[id] == ref.Type && strings.EqualFold(name, ref.Name) {
+			candidates = append(candidates, id)
+		}
+	}
+	return pickCandidate(candidates)
+}
+
+// resolveLight returns the ID of the light matching the name, or the reason why it cannot be resolved. The name is
+// looked up among the lights of the given room or zone first, as light names are often only unique within a room,
+// then among all the lights of the bridge. The group ID may be empty when it is not known.
+func (idx *nameIndex) resolveLight(groupId string, name string) (string, string) {
+	var inGroup, candidates []string
+	for id, n := range idx.lightNames {
+		if strings.EqualFold(n, name) {
+			candidates = append(candidates, id)
+			if idx.groupLights[groupId][id] {
+				inGroup = append(inGroup, id)
+			}
+		}
+	}
+	if len(inGroup) > 0 {
+		return pickCandidate(inGroup)
+	}
+	return pickCandidate(candidates)
+}
+
+func pickCandidate(candidates []string) (string, string) {
+	switch len(candidates) {
+	case 0:
+		return "", "not found"
+	case 1:
+		return candidates[0], ""
+	default:
+		return "", "is ambiguous"
+	}
+}
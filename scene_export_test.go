@@ -0,0 +1,214 @@
+package openhue
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportScenes(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetScenesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetScenesResponse](t, `[{
+		"id": "scene-1", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Relax"}, "speed": 0.5,
+		"actions": [
+			{"target": {"rid": "light-1"}, "action": {"on": {"on": true}, "dimming": {"brightness": 80}}},
+			{"target": {"rid": "unknown-light"}}
+		]
+	}]`), nil)
+	m.On("GetRoomsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetRoomsResponse](t, `[{"id": "room-1", "metadata": {"name": "Living Room"}}]`), nil)
+	m.On("GetZonesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetZonesResponse](t, `[]`), nil)
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[{"id": "light-1", "metadata": {"name": "Ceiling"}}]`), nil)
+
+	doc, err := home.ExportScenes(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, doc.Scenes, 1)
+
+	ps := doc.Scenes[0]
+	assert.Equal(t, "Relax", ps.Name)
+	assert.Equal(t, PortableGroupRef{Name: "Living Room", Type: ResourceIdentifierRtypeRoom}, ps.Group)
+	assert.Equal(t, float32(0.5), *ps.Speed)
+	assert.Len(t, ps.Actions, 1)
+	assert.Equal(t, "Ceiling", ps.Actions[0].Light)
+	assert.Equal(t, float32(80), *ps.Actions[0].Brightness)
+}
+
+func TestExportScenes_StableOrder(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetScenesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetScenesResponse](t, `[
+		{"id": "scene-1", "group": {"rid": "room-2", "rtype": "room"}, "metadata": {"name": "Relax"}},
+		{"id": "scene-2", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Relax"}},
+		{"id": "scene-3", "group": {"rid": "room-2", "rtype": "room"}, "metadata": {"name": "Energize"}},
+		{"id": "scene-4", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Read"}},
+		{"id": "scene-5", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Concentrate"}}
+	]`), nil)
+	m.On("GetRoomsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetRoomsResponse](t, `[
+		{"id": "room-1", "metadata": {"name": "Living Room"}},
+		{"id": "room-2", "metadata": {"name": "Bedroom"}}
+	]`), nil)
+	m.On("GetZonesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetZonesResponse](t, `[]`), nil)
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[]`), nil)
+
+	// map iteration order is random, export several times
+	for range 10 {
+		doc, err := home.ExportScenes(context.Background())
+		assert.NoError(t, err)
+
+		var names []string
+		for _, ps := range doc.Scenes {
+			names = append(names, ps.Group.Name+"/"+ps.Name)
+		}
+		assert.Equal(t, []string{
+			"Bedroom/Energize",
+			"Bedroom/Relax",
+			"Living Room/Concentrate",
+			"Living Room/Read",
+			"Living Room/Relax",
+		}, names)
+	}
+}
+
+func TestSceneDocument_YAMLRoundTrip(t *testing.T) {
+	doc := &SceneDocument{Scenes: []PortableScene{{
+		Name:  "Relax",
+		Group: PortableGroupRef{Name: "Living Room", Type: ResourceIdentifierRtypeRoom},
+		Actions: []PortableAction{
+			{Light: "Ceiling", On: ptr(true), Mirek: ptr(366), Effect: ptr(Candle)},
+		},
+	}}}
+
+	data, err := doc.YAML()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "light: Ceiling")
+
+	parsed, err := ParseSceneDocument(data)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, parsed)
+
+	data, err = doc.JSON()
+	assert.NoError(t, err)
+
+	parsed, err = ParseSceneDocument(data)
+	assert.NoError(t, err)
+	assert.Equal(t, doc, parsed)
+}
+
+func TestImportScenes(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetScenesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetScenesResponse](t, `[
+		{"id": "scene-1", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Relax"}}
+	]`), nil)
+	m.On("GetRoomsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetRoomsResponse](t, `[{"id": "room-1", "metadata": {"name": "Living Room"}}]`), nil)
+	m.On("GetZonesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetZonesResponse](t, `[]`), nil)
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[{"id": "light-1", "metadata": {"name": "Ceiling"}}]`), nil)
+	m.On("UpdateSceneWithResponse", mock.Anything, "scene-1", mock.Anything, mock.Anything).Return(&UpdateSceneResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil)
+	m.On("CreateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateSceneResponse](t, `[{"rid": "scene-2", "rtype": "scene"}]`), nil)
+
+	doc := &SceneDocument{Scenes: []PortableScene{
+		{
+			Name:    "Relax",
+			Group:   PortableGroupRef{Name: "living room", Type: ResourceIdentifierRtypeRoom},
+			Actions: []PortableAction{{Light: "Ceiling", On: ptr(true)}, {Light: "Lamp", On: ptr(true)}},
+		},
+		{
+			Name:    "Read",
+			Group:   PortableGroupRef{Name: "Living Room", Type: ResourceIdentifierRtypeRoom},
+			Actions: []PortableAction{{Light: "Ceiling", Brightness: ptr(float32(100))}},
+		},
+		{
+			Name:    "Cook",
+			Group:   PortableGroupRef{Name: "Kitchen", Type: ResourceIdentifierRtypeRoom},
+			Actions: []PortableAction{{Light: "Ceiling"}},
+		},
+	}}
+
+	report, err := home.ImportScenes(context.Background(), doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Relax"}, report.Updated)
+	assert.Equal(t, []string{"Read"}, report.Created)
+	assert.Equal(t, []string{"Cook"}, report.Skipped)
+	assert.Equal(t, []UnmatchedReference{
+		{Scene: "Relax", Type: ResourceIdentifierRtypeLight, Name: "Lamp", Reason: "not found"},
+		{Scene: "Cook", Type: ResourceIdentifierRtypeRoom, Name: "Kitchen", Reason: "not found"},
+	}, report.Unmatched)
+}
+
+func TestImportScenes_LightNamesWithinGroup(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetScenesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetScenesResponse](t, `[]`), nil)
+	m.On("GetRoomsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetRoomsResponse](t, `[
+		{"id": "room-1", "metadata": {"name": "Kitchen"}, "children": [{"rid": "dev-1", "rtype": "device"}]},
+		{"id": "room-2", "metadata": {"name": "Bedroom"}, "children": [{"rid": "dev-2", "rtype": "device"}]}
+	]`), nil)
+	m.On("GetZonesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetZonesResponse](t, `[{"id": "zone-1", "metadata": {"name": "Upstairs"}, "children": [{"rid": "light-2", "rtype": "light"}]}]`), nil)
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[
+		{"id": "light-1", "metadata": {"name": "Ceiling"}, "owner": {"rid": "dev-1", "rtype": "device"}},
+		{"id": "light-2", "metadata": {"name": "Ceiling"}, "owner": {"rid": "dev-2", "rtype": "device"}},
+		{"id": "light-3", "metadata": {"name": "Desk"}, "owner": {"rid": "dev-3", "rtype": "device"}}
+	]`), nil)
+	m.On("CreateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateSceneResponse](t, `[{"rid": "scene-new", "rtype": "scene"}]`), nil)
+
+	doc := &SceneDocument{Scenes: []PortableScene{
+		{
+			Name:  "Cook",
+			Group: PortableGroupRef{Name: "Kitchen", Type: ResourceIdentifierRtypeRoom},
+			// the desk lamp is in no room, it is found among all the lights of the bridge
+			Actions: []PortableAction{{Light: "Ceiling", On: ptr(true)}, {Light: "Desk", On: ptr(true)}},
+		},
+		{
+			Name:    "Night",
+			Group:   PortableGroupRef{Name: "Upstairs", Type: ResourceIdentifierRtypeZone},
+			Actions: []PortableAction{{Light: "Ceiling", On: ptr(false)}},
+		},
+	}}
+
+	report, err := home.ImportScenes(context.Background(), doc)
+	assert.NoError(t, err)
+	assert.Empty(t, report.Unmatched)
+	assert.Equal(t, []string{"Cook", "Night"}, report.Created)
+
+	m.AssertCalled(t, "CreateSceneWithResponse", mock.Anything, mock.MatchedBy(func(body ScenePost) bool {
+		return *body.Group.Rid == "room-1" && len(body.Actions) == 2 &&
+			*body.Actions[0].Target.Rid == "light-1" && *body.Actions[1].Target.Rid == "light-3"
+	}), mock.Anything)
+	m.AssertCalled(t, "CreateSceneWithResponse", mock.Anything, mock.MatchedBy(func(body ScenePost) bool {
+		return *body.Group.Rid == "zone-1" && len(body.Actions) == 1 && *body.Actions[0].Target.Rid == "light-2"
+	}), mock.Anything)
+}
+
+func TestImportScenes_DuplicateNames(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetScenesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetScenesResponse](t, `[
+		{"id": "scene-b", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Relax"}},
+		{"id": "scene-a", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Relax"}}
+	]`), nil)
+	m.On("GetRoomsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetRoomsResponse](t, `[{"id": "room-1", "metadata": {"name": "Living Room"}}]`), nil)
+	m.On("GetZonesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetZonesResponse](t, `[]`), nil)
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[{"id": "light-1", "metadata": {"name": "Ceiling"}}]`), nil)
+	m.On("UpdateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&UpdateSceneResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil)
+	m.On("CreateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateSceneResponse](t, `[{"rid": "scene-new", "rtype": "scene"}]`), nil)
+
+	room := PortableGroupRef{Name: "Living Room", Type: ResourceIdentifierRtypeRoom}
+	doc := &SceneDocument{Scenes: []PortableScene{
+		{Name: "Relax", Group: room, Actions: []PortableAction{{Light: "Ceiling", On: ptr(true)}}},
+		{Name: "Read", Group: room, Actions: []PortableAction{{Light: "Ceiling", On: ptr(true)}}},
+		{Name: "Read", Group: room, Actions: []PortableAction{{Light: "Ceiling", On: ptr(false)}}},
+	}}
+
+	report, err := home.ImportScenes(context.Background(), doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Read"}, report.Created)
+	assert.Equal(t, []string{"Relax", "Read"}, report.Updated)
+
+	m.AssertNumberOfCalls(t, "CreateSceneWithResponse", 1)
+	m.AssertCalled(t, "UpdateSceneWithResponse", mock.Anything, "scene-a", mock.Anything, mock.Anything)
+	m.AssertCalled(t, "UpdateSceneWithResponse", mock.Anything, "scene-new", mock.Anything, mock.Anything)
+	m.AssertNotCalled(t, "UpdateSceneWithResponse", mock.Anything, "scene-b", mock.Anything, mock.Anything)
+}
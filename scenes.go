@@ -0,0 +1,685 @@
+package openhue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SCENE CAPTURE
+//--------------------------------------------------------------------------------------------------------------------//
+
+// CaptureScene reads the current state of every light in the given room or zone and saves it as a new scene
+// named after the name parameter. This is the equivalent of the "save current state as scene" feature of the Hue app.
+//
+// Example:
+//
+//	rtype := openhue.ResourceIdentifierRtypeRoom
+//	room := openhue.ResourceIdentifier{Rid: &roomId, Rtype: &rtype}
+//	scene, err := home.CaptureScene(ctx, room, "Movie Night")
+func (h *Home) CaptureScene(ctx context.Context, groupRef ResourceIdentifier, name string) (*ResourceIdentifier, error) {
+
+	if name == "" {
+		return nil, errors.New("illegal arguments, scene name must be set")
+	}
+
+	lightIds, err := h.getGroupLightIds(ctx, groupRef)
+	if err != nil {
+		return nil, err
+	}
+
+	lights, err := h.GetLights(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]ActionPost, 0, len(lightIds))
+	for _, lightId := range lightIds {
+		light, ok := lights[lightId]
+		if !ok {
+			continue
+		}
+		actions = append(actions, light.sceneAction())
+	}
+
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("no light found in %s %s", *groupRef.Rtype, *groupRef.Rid)
+	}
+
+	sceneType := ScenePostTypeScene
+	return h.CreateScene(ctx, ScenePost{
+		Actions:  actions,
+		Group:    groupRef,
+		Metadata: SceneMetadata{Name: &name},
+		Type:     &sceneType,
+	})
+}
+
+// sceneAction converts the current state of the light into a scene action targeting this light.
+// Lights that are off only carry their on/off state, color and dimming are ignored by the bridge in that case.
+func (l *LightGet) sceneAction() ActionPost {
+
+	lightType := ResourceIdentifierRtypeLight
+	action := ActionPost{
+		Target: ResourceIdentifier{Rid: l.Id, Rtype: &lightType},
+	}
+
+	if l.On != nil && l.On.On != nil {
+		on := *l.On.On
+		action.Action.On = &On{On: &on}
+		if !on {
+			return action
+		}
+	}
+
+	if l.Dimming != nil && l.Dimming.Brightness != nil {
+		brightness := *l.Dimming.Brightness
+		action.Action.Dimming = &Dimming{Brightness: &brightness}
+	}
+
+	if l.Gradient != nil && l.Gradient.Points != nil && len(*l.Gradient.Points) >= 2 {
+		points := append([]Color(nil), *l.Gradient.Points...)
+		action.Action.Gradient = &Gradient{Mode: l.Gradient.Mode, Points: &points}
+	}
+
+	if l.ColorTemperature != nil && l.ColorTemperature.MirekValid != nil && *l.ColorTemperature.MirekValid &&
+		l.ColorTemperature.Mirek != nil {
+		mirek := *l.ColorTemperature.Mirek
+		action.Action.ColorTemperature = &struct {
+			Mirek *Mirek `json:"mirek,omitempty"`
+		}{Mirek: &mirek}
+	} else if l.Color != nil && l.Color.Xy != nil {
+		xy := *l.Color.Xy
+		action.Action.Color = &Color{Xy: &xy}
+	}
+
+	if l.Effects != nil && l.Effects.Status != nil && *l.Effects.Status != NoEffect {
+		effect := *l.Effects.Status
+		action.Action.Effects = &struct {
+			Effect *SupportedEffects `json:"effect,omitempty"`
+		}{Effect: &effect}
+	}
+
+	return action
+}
+
+// getGroupLightIds returns the IDs of all the lights contained in a room or a zone.
+// Rooms group devices, so their light services are looked up from the devices, whereas zones can directly
+// reference light services.
+func (h *Home) getGroupLightIds(ctx context.Context, groupRef ResourceIdentifier) ([]string, error) {
+
+	if groupRef.Rid == nil || groupRef.Rtype == nil {
+		return nil, errors.New("illegal arguments, group rid and rtype must be set")
+	}
+
+	var group *RoomGet
+	var err error
+
+	switch *groupRef.Rtype {
+	case ResourceIdentifierRtypeRoom:
+		group, err = h.GetRoomById(ctx, *groupRef.Rid)
+	case ResourceIdentifierRtypeZone:
+		group, err = h.GetZoneById(ctx, *groupRef.Rid)
+	default:
+		return nil, fmt.Errorf("unsupported group type '%s', expected room or zone", *groupRef.Rtype)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if group.Children == nil {
+		return nil, nil
+	}
+
+	var devices map[string]DeviceGet
+	var lightIds []string
+	seen := make(map[string]bool)
+
+	for _, child := range *group.Children {
+		if child.Rid == nil || child.Rtype == nil {
+			continue
+		}
+
+		switch *child.Rtype {
+		case ResourceIdentifierRtypeLight:
+			if !seen[*child.Rid] {
+				seen[*child.Rid] = true
+				lightIds = append(lightIds, *child.Rid)
+			}
+		case ResourceIdentifierRtypeDevice:
+			if devices == nil {
+				devices, err = h.GetDevices(ctx)
+				if err != nil {
+					return nil, err
+				}
+			}
+			device, ok := devices[*child.Rid]
+			if !ok || device.Services == nil {
+				continue
+			}
+			for _, s := range *device.Services {
+				if s.Rid != nil && s.Rtype != nil && *s.Rtype == ResourceIdentifierRtypeLight && !seen[*s.Rid] {
+					seen[*s.Rid] = true
+					lightIds = append(lightIds, *s.Rid)
+				}
+			}
+		}
+	}
+
+	return lightIds, nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SCENE RECALL
+//--------------------------------------------------------------------------------------------------------------------//
+
+// SceneRecallOption is a functional option for configuring how a scene is recalled by Home.ActivateScene.
+type SceneRecallOption func(*ScenePut) error
+
+// WithSceneTransition sets the duration of the transition from the current state of the lights to the scene.
+// The bridge handles durations with a resolution of 100ms.
+func WithSceneTransition(duration time.Duration) SceneRecallOption {
+	return func(p *ScenePut) error {
+		if duration < 0 {
+			return errors.New("transition duration cannot be negative")
+		}
+		ms := int(duration.Milliseconds())
+		p.Recall.Duration = &ms
+		return nil
+	}
+}
+
+// WithSceneBrightness overrides the brightness of the scene, as a percentage between 0 and 100.
+func WithSceneBrightness(brightness float32) SceneRecallOption {
+	return func(p *ScenePut) error {
+		if brightness < 0 || brightness > 100 {
+			return errors.New("brightness must be between 0 and 100")
+		}
+		p.Recall.Dimming = &Dimming{Brightness: &brightness}
+		return nil
+	}
+}
+
+// WithSceneDynamicPalette recalls the scene dynamically, cycling through the colors of its palette.
+func WithSceneDynamicPalette() SceneRecallOption {
+	return func(p *ScenePut) error {
+		action := SceneRecallActionDynamicPalette
+		p.Recall.Action = &action
+		return nil
+	}
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SCENE SPEED
+//--------------------------------------------------------------------------------------------------------------------//
+
+// SetSceneSpeed sets the speed of the dynamic palette of a scene, between 0 (slowest) and 1 (fastest). Unlike the
+// SceneRecallOption functions, it changes the scene stored on the bridge, so it applies to all the later recalls.
+// It is only relevant for scenes recalled with WithSceneDynamicPalette or having auto dynamic enabled.
+func (h *Home) SetSceneSpeed(ctx context.Context, sceneId string, speed float32) error {
+	if speed < 0 || speed > 1 {
+		return errors.New("speed must be between 0 and 1")
+	}
+	return h.UpdateScene(ctx, sceneId, ScenePut{Speed: &speed})
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SCENE MATCHING
+//--------------------------------------------------------------------------------------------------------------------//
+
+// SceneMatchTolerance defines how far the live state of a light can drift from a scene action while still being
+// considered as matching it.
+type SceneMatchTolerance struct {
+	// Xy is the maximum euclidean distance between two CIE xy color points.
+	Xy float64
+	// Mirek is the maximum color temperature difference, in mirek.
+	Mirek int
+	// Brightness is the maximum brightness difference, in percentage points.
+	Brightness float32
+}
+
+// DefaultSceneMatchTolerance absorbs the rounding applied by the lights when a scene is recalled.
+var DefaultSceneMatchTolerance = SceneMatchTolerance{Xy: 0.01, Mirek: 5, Brightness: 2}
+
+// SceneMatch is the result of the comparison between a scene and the live state of its lights.
+type SceneMatch struct {
+	SceneId string
+	Lights  []LightMatch
+	// Score is the average score of the lights, from 0 (nothing matches) to 1 (the scene is effectively applied).
+	Score float64
+}
+
+// IsApplied returns true when every light of the scene is in the state defined by the scene.
+func (m *SceneMatch) IsApplied() bool {
+	if len(m.Lights) == 0 {
+		return false
+	}
+	for _, l := range m.Lights {
+		if !l.Matched {
+			return false
+		}
+	}
+	return true
+}
+
+// LightMatch is the result of the comparison between a scene action and the live state of the light it targets.
+type LightMatch struct {
+	LightId string
+	Matched bool
+	// Score is the ratio of properties of the scene action that match the light state, from 0 to 1.
+	Score float64
+	// Mismatches lists the properties that do not match: "light", "on", "brightness", "color", "color_temperature",
+	// "gradient" or "effect".
+	Mismatches []string
+}
+
+// MatchScene compares the live state of the lights targeted by a scene with the scene actions. Unlike the
+// status reported by the bridge, the result remains accurate when lights are changed outside the scene, for instance
+// a scene is still considered as applied when lights are manually set back to it.
+func (h *Home) MatchScene(ctx context.Context, sceneId string, tolerance SceneMatchTolerance) (*SceneMatch, error) {
+
+	scene, err := h.GetSceneById(ctx, sceneId)
+	if err != nil {
+		return nil, err
+	}
+
+	lights, err := h.GetLights(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return MatchSceneState(*scene, lights, tolerance), nil
+}
+
+// MatchSceneState compares a scene with the given light states, indexed by light ID, without calling the bridge.
+func MatchSceneState(scene SceneGet, lights map[string]LightGet, tolerance SceneMatchTolerance) *SceneMatch {
+
+	match := &SceneMatch{}
+	if scene.Id != nil {
+		match.SceneId = *scene.Id
+	}
+	if scene.Actions == nil {
+		return match
+	}
+
+	var total float64
+	for _, a := range *scene.Actions {
+		if a.Target == nil || a.Target.Rid == nil {
+			continue
+		}
+
+		var lm LightMatch
+		if light, ok := lights[*a.Target.Rid]; ok {
+			lm = matchLight(a, light, tolerance)
+		} else {
+			lm = LightMatch{Mismatches: []string{"light"}}
+		}
+		lm.LightId = *a.Target.Rid
+
+		total += lm.Score
+		match.Lights = append(match.Lights, lm)
+	}
+
+	if len(match.Lights) > 0 {
+		match.Score = total / float64(len(match.Lights))
+	}
+
+	return match
+}
+
+func matchLight(a ActionGet, l LightGet, tol SceneMatchTolerance) LightMatch {
+
+	lm := LightMatch{Matched: true, Score: 1}
+	if a.Action == nil {
+		return lm
+	}
+
+	var checked, matched int
+	check := func(property string, ok bool) {
+		checked++
+		if ok {
+			matched++
+		} else {
+			lm.Mismatches = append(lm.Mismatches, property)
+		}
+	}
+
+	lightOn := l.On != nil && l.On.On != nil && *l.On.On
+	if a.Action.On != nil && a.Action.On.On != nil {
+		check("on", *a.Action.On.On == lightOn)
+		if !*a.Action.On.On || !lightOn {
+			// Other properties are irrelevant when the light is expected to be, or actually is, off
+			return lm.withScore(checked, matched)
+		}
+	}
+
+	if a.Action.Dimming != nil && a.Action.Dimming.Brightness != nil {
+		ok := l.Dimming != nil && l.Dimming.Brightness != nil &&
+			abs(*l.Dimming.Brightness-*a.Action.Dimming.Brightness) <= tol.Brightness
+		check("brightness", ok)
+	}
+
+	if a.Action.ColorTemperature != nil && a.Action.ColorTemperature.Mirek != nil {
+		ok := l.ColorTemperature != nil && l.ColorTemperature.Mirek != nil &&
+			(l.ColorTemperature.MirekValid == nil || *l.ColorTemperature.MirekValid) &&
+			abs(*l.ColorTemperature.Mirek-*a.Action.ColorTemperature.Mirek) <= tol.Mirek
+		check("color_temperature", ok)
+	}
+
+	if a.Action.Color != nil && a.Action.Color.Xy != nil {
+		ok := l.Color != nil && l.Color.Xy != nil && xyDistance(*l.Color.Xy, *a.Action.Color.Xy) <= tol.Xy
+		check("color", ok)
+	}
+
+	if a.Action.Gradient != nil && a.Action.Gradient.Points != nil {
+		ok := l.Gradient != nil && l.Gradient.Points != nil && len(*l.Gradient.Points) == len(*a.Action.Gradient.Points)
+		for i := 0; ok && i < len(*a.Action.Gradient.Points); i++ {
+			expected, actual := (*a.Action.Gradient.Points)[i], (*l.Gradient.Points)[i]
+			ok = expected.Xy != nil && actual.Xy != nil && xyDistance(*actual.Xy, *expected.Xy) <= tol.Xy
+		}
+		check("gradient", ok)
+	}
+
+	if a.Action.Effects != nil && a.Action.Effects.Effect != nil {
+		current := NoEffect
+		if l.Effects != nil && l.Effects.Status != nil {
+			current = *l.Effects.Status
+		}
+		check("effect", current == *a.Action.Effects.Effect)
+	}
+
+	return lm.withScore(checked, matched)
+}
+
+func (lm LightMatch) withScore(checked, matched int) LightMatch {
+	lm.Matched = checked == matched
+	if checked > 0 {
+		lm.Score = float64(matched) / float64(checked)
+	}
+	return lm
+}
+
+func xyDistance(a, b GamutPosition) float64 {
+	if a.X == nil || a.Y == nil || b.X == nil || b.Y == nil {
+		return math.Inf(1)
+	}
+	return math.Hypot(float64(*a.X-*b.X), float64(*a.Y-*b.Y))
+}
+
+func abs[T int | float32](v T) T {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SCENE CLONING
+//--------------------------------------------------------------------------------------------------------------------//
+
+// SceneMapping defines how the actions of a scene are distributed over the lights of another group when cloning it.
+type SceneMapping int
+
+const (
+	// MapByPosition maps the actions in the order of the scene onto the lights in the order of the target group.
+	// When the light counts differ, actions are spread evenly over the target lights.
+	MapByPosition SceneMapping = iota
+	// MapByArchetype maps each target light to an action of a source light sharing the same archetype (e.g. a
+	// ceiling spot gets the state of a ceiling spot) and falls back to MapByPosition when there is none.
+	MapByArchetype
+)
+
+type sceneCloneConfig struct {
+	name    string
+	mapping SceneMapping
+}
+
+// SceneCloneOption is a functional option for configuring Home.CloneScene.
+type SceneCloneOption func(*sceneCloneConfig)
+
+// WithCloneName sets the name of the cloned scene, which defaults to the name of the source scene.
+func WithCloneName(name string) SceneCloneOption {
+	return func(c *sceneCloneConfig) {
+		c.name = name
+	}
+}
+
+// WithCloneMapping sets how the actions are mapped onto the lights of the target group. Defaults to MapByPosition.
+func WithCloneMapping(mapping SceneMapping) SceneCloneOption {
+	return func(c *sceneCloneConfig) {
+		c.mapping = mapping
+	}
+}
+
+// CloneScene copies a scene, including its palette and metadata, into another room or zone. The actions of the
+// scene are mapped onto the lights of the target group according to the SceneMapping, MapByPosition by default.
+//
+// Example:
+//
+//	rtype := openhue.ResourceIdentifierRtypeRoom
+//	office := openhue.ResourceIdentifier{Rid: &officeId, Rtype: &rtype}
+//	scene, err := home.CloneScene(ctx, focusSceneId, office, openhue.WithCloneMapping(openhue.MapByArchetype))
+func (h *Home) CloneScene(ctx context.Context, sceneId string, targetGroup ResourceIdentifier, opts ...SceneCloneOption) (*ResourceIdentifier, error) {
+
+	scene, err := h.GetSceneById(ctx, sceneId)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &sceneCloneConfig{mapping: MapByPosition}
+	if scene.Metadata != nil && scene.Metadata.Name != nil {
+		cfg.name = *scene.Metadata.Name
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if scene.Actions == nil || len(*scene.Actions) == 0 {
+		return nil, fmt.Errorf("scene %s has no action to clone", sceneId)
+	}
+
+	targetLightIds, err := h.getGroupLightIds(ctx, targetGroup)
+	if err != nil {
+		return nil, err
+	}
+	if len(targetLightIds) == 0 {
+		return nil, fmt.Errorf("no light found in %s %s", *targetGroup.Rtype, *targetGroup.Rid)
+	}
+
+	var lights map[string]LightGet
+	if cfg.mapping == MapByArchetype {
+		lights, err = h.GetLights(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body := ScenePost{
+		Actions:     mapSceneActions(*scene.Actions, targetLightIds, cfg.mapping, lights),
+		Group:       targetGroup,
+		Metadata:    SceneMetadata{Name: &cfg.name},
+		Palette:     scene.Palette,
+		Speed:       scene.Speed,
+		AutoDynamic: scene.AutoDynamic,
+	}
+	if scene.Metadata != nil {
+		body.Metadata.Appdata = scene.Metadata.Appdata
+		body.Metadata.Image = scene.Metadata.Image
+	}
+	sceneType := ScenePostTypeScene
+	body.Type = &sceneType
+
+	return h.CreateScene(ctx, body)
+}
+
+// mapSceneActions assigns one of the source actions to each target light. The lights are only required for
+// MapByArchetype, in order to look up the archetypes of the source and target lights.
+func mapSceneActions(source []ActionGet, targetLightIds []string, mapping SceneMapping, lights map[string]LightGet) []ActionPost {
+
+	byArchetype := make(map[LightArchetype][]int)
+	if mapping == MapByArchetype {
+		for i, a := range source {
+			if a.Target == nil || a.Target.Rid == nil {
+				continue
+			}
+			if archetype, ok := lightArchetype(lights, *a.Target.Rid); ok {
+				byArchetype[archetype] = append(byArchetype[archetype], i)
+			}
+		}
+	}
+
+	used := make(map[LightArchetype]int)
+	actions := make([]ActionPost, 0, len(targetLightIds))
+
+	for i, lightId := range targetLightIds {
+		// spread the source actions evenly over the target lights
+		index := i * len(source) / len(targetLightIds)
+
+		if archetype, ok := lightArchetype(lights, lightId); ok && len(byArchetype[archetype]) > 0 {
+			candidates := byArchetype[archetype]
+			index = candidates[used[archetype]%len(candidates)]
+			used[archetype]++
+		}
+
+		actions = append(actions, source[index].retarget(lightId))
+	}
+
+	return actions
+}
+
+func lightArchetype(lights map[string]LightGet, lightId string) (LightArchetype, bool) {
+	light, ok := lights[lightId]
+	if !ok || light.Metadata == nil || light.Metadata.Archetype == nil {
+		return "", false
+	}
+	return *light.Metadata.Archetype, true
+}
+
+// retarget copies the action of a scene so that it applies to another light.
+func (a *ActionGet) retarget(lightId string) ActionPost {
+
+	lightType := ResourceIdentifierRtypeLight
+	action := ActionPost{
+		Target: ResourceIdentifier{Rid: &lightId, Rtype: &lightType},
+	}
+	if a.Action == nil {
+		return action
+	}
+
+	action.Action.On = a.Action.On
+	action.Action.Dimming = a.Action.Dimming
+	action.Action.Color = a.Action.Color
+	action.Action.Gradient = a.Action.Gradient
+	action.Action.Effects = a.Action.Effects
+	if a.Action.ColorTemperature != nil {
+		action.Action.ColorTemperature = &struct {
+			Mirek *Mirek `json:"mirek,omitempty"`
+		}{Mirek: a.Action.ColorTemperature.Mirek}
+	}
+
+	return action
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SCENE ACTION EDITING
+//--------------------------------------------------------------------------------------------------------------------//
+
+// SetSceneAction replaces the action of a light in a scene, the light being identified by the target of the action.
+// It returns an error wrapping ErrNotFound if the light is not part of the scene, and ErrSceneModified if the scene
+// actions have been changed by someone else in the meantime.
+func (h *Home) SetSceneAction(ctx context.Context, sceneId string, action ActionPost) error {
+	if action.Target.Rid == nil {
+		return errors.New("illegal arguments, action target rid must be set")
+	}
+	lightId := *action.Target.Rid
+
+	return h.editSceneActions(ctx, sceneId, func(actions []ActionPost) ([]ActionPost, error) {
+		i := indexOfSceneLight(actions, lightId)
+		if i < 0 {
+			return nil, fmt.Errorf("light %s is not part of scene %s: %w", lightId, sceneId, ErrNotFound)
+		}
+		actions[i] = action
+		return actions, nil
+	})
+}
+
+// AddSceneLight adds a light to a scene with the given action, the light being identified by the target of the
+// action. It returns an error wrapping ErrConflict if the light is already part of the scene, and ErrSceneModified
+// if the scene actions have been changed by someone else in the meantime.
+func (h *Home) AddSceneLight(ctx context.Context, sceneId string, action ActionPost) error {
+	if action.Target.Rid == nil {
+		return errors.New("illegal arguments, action target rid must be set")
+	}
+	lightId := *action.Target.Rid
+
+	return h.editSceneActions(ctx, sceneId, func(actions []ActionPost) ([]ActionPost, error) {
+		if indexOfSceneLight(actions, lightId) >= 0 {
+			return nil, fmt.Errorf("light %s is already part of scene %s: %w", lightId, sceneId, ErrConflict)
+		}
+		return append(actions, action), nil
+	})
+}
+
+// RemoveSceneLight removes a light from a scene. It returns an error wrapping ErrNotFound if the light is not part
+// of the scene, and ErrSceneModified if the scene actions have been changed by someone else in the meantime.
+// The last light of a scene cannot be removed, delete the scene instead.
+func (h *Home) RemoveSceneLight(ctx context.Context, sceneId string, lightId string) error {
+	return h.editSceneActions(ctx, sceneId, func(actions []ActionPost) ([]ActionPost, error) {
+		i := indexOfSceneLight(actions, lightId)
+		if i < 0 {
+			return nil, fmt.Errorf("light %s is not part of scene %s: %w", lightId, sceneId, ErrNotFound)
+		}
+		if len(actions) == 1 {
+			return nil, fmt.Errorf("light %s is the last light of scene %s, delete the scene instead", lightId, sceneId)
+		}
+		return append(actions[:i], actions[i+1:]...), nil
+	})
+}
+
+// editSceneActions performs a read-modify-write of the actions of a scene. The scene is read again right before
+// writing, and the update is aborted with ErrSceneModified if its actions differ from the ones that were edited.
+func (h *Home) editSceneActions(ctx context.Context, sceneId string, edit func([]ActionPost) ([]ActionPost, error)) error {
+
+	scene, err := h.GetSceneById(ctx, sceneId)
+	if err != nil {
+		return err
+	}
+
+	var actions []ActionPost
+	if scene.Actions != nil {
+		for _, a := range *scene.Actions {
+			if a.Target == nil || a.Target.Rid == nil {
+				continue
+			}
+			actions = append(actions, a.retarget(*a.Target.Rid))
+		}
+	}
+
+	actions, err = edit(actions)
+	if err != nil {
+		return err
+	}
+
+	current, err := h.GetSceneById(ctx, sceneId)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(scene.Actions, current.Actions) {
+		return fmt.Errorf("unable to update scene %s: %w", sceneId, ErrSceneModified)
+	}
+
+	return h.UpdateScene(ctx, sceneId, ScenePut{Actions: &actions})
+}
+
+func indexOfSceneLight(actions []ActionPost, lightId string) int {
+	for i, a := range actions {
+		if a.Target.Rid != nil && *a.Target.Rid == lightId {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,294 @@
+package openhue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCaptureScene(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetRoomWithResponse", mock.Anything, "room-1", mock.Anything).Return(dataResponse[*GetRoomResponse](t, `[
+		{"id": "room-1", "children": [{"rid": "device-1", "rtype": "device"}]}
+	]`), nil)
+	m.On("GetDevicesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetDevicesResponse](t, `[
+		{"id": "device-1", "services": [{"rid": "light-1", "rtype": "light"}, {"rid": "zigbee-1", "rtype": "zigbee_connectivity"}]}
+	]`), nil)
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[
+		{"id": "light-1", "on": {"on": true}, "dimming": {"brightness": 42}, "color_temperature": {"mirek": 366, "mirek_valid": true}}
+	]`), nil)
+
+	var captured ScenePost
+	m.On("CreateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { captured = args.Get(1).(ScenePost) }).
+		Return(dataResponse[*CreateSceneResponse](t, `[{"rid": "scene-1", "rtype": "scene"}]`), nil)
+
+	scene, err := home.CaptureScene(context.Background(), ResourceIdentifier{Rid: ptr("room-1"), Rtype: ptr(ResourceIdentifierRtypeRoom)}, "Evening")
+	assert.NoError(t, err)
+	assert.Equal(t, "scene-1", *scene.Rid)
+
+	assert.Equal(t, "Evening", *captured.Metadata.Name)
+	assert.Equal(t, "room-1", *captured.Group.Rid)
+	assert.Len(t, captured.Actions, 1)
+
+	action := captured.Actions[0]
+	assert.Equal(t, "light-1", *action.Target.Rid)
+	assert.True(t, *action.Action.On.On)
+	assert.Equal(t, float32(42), *action.Action.Dimming.Brightness)
+	assert.Equal(t, 366, *action.Action.ColorTemperature.Mirek)
+	assert.Nil(t, action.Action.Color)
+}
+
+func TestCaptureScene_UnsupportedGroup(t *testing.T) {
+	home, _ := NewTestHome()
+
+	_, err := home.CaptureScene(context.Background(), ResourceIdentifier{Rid: ptr("light-1"), Rtype: ptr(ResourceIdentifierRtypeLight)}, "Evening")
+	assert.ErrorContains(t, err, "unsupported group type")
+}
+
+func TestLightGet_SceneAction_Off(t *testing.T) {
+	light := LightGet{Id: ptr("light-1"), On: &On{On: ptr(false)}}
+
+	action := light.sceneAction()
+	assert.False(t, *action.Action.On.On)
+	assert.Nil(t, action.Action.Dimming)
+	assert.Nil(t, action.Action.Color)
+}
+
+func TestActivateScene_WithOptions(t *testing.T) {
+	home, m := NewTestHome()
+
+	var body ScenePut
+	m.On("UpdateSceneWithResponse", mock.Anything, "scene-1", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { body = args.Get(2).(ScenePut) }).
+		Return(&UpdateSceneResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	err := home.ActivateScene(context.Background(), "scene-1",
+		WithSceneTransition(2*time.Minute),
+		WithSceneBrightness(50),
+		WithSceneDynamicPalette(),
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, SceneRecallActionDynamicPalette, *body.Recall.Action)
+	assert.Equal(t, 120000, *body.Recall.Duration)
+	assert.Equal(t, float32(50), *body.Recall.Dimming.Brightness)
+	assert.Nil(t, body.Speed)
+}
+
+func TestActivateScene_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     SceneRecallOption
+		wantErr string
+	}{
+		{"negative transition", WithSceneTransition(-time.Second), "transition duration cannot be negative"},
+		{"brightness too high", WithSceneBrightness(101), "brightness must be between 0 and 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home, _ := NewTestHome()
+			err := home.ActivateScene(context.Background(), "scene-1", tt.opt)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSetSceneSpeed(t *testing.T) {
+	home, m := NewTestHome()
+	m.On("UpdateSceneWithResponse", mock.Anything, "scene-1", mock.Anything, mock.Anything).Return(&UpdateSceneResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	assert.NoError(t, home.SetSceneSpeed(context.Background(), "scene-1", 0.3))
+	assert.ErrorContains(t, home.SetSceneSpeed(context.Background(), "scene-1", 1.5), "speed must be between 0 and 1")
+
+	m.AssertNumberOfCalls(t, "UpdateSceneWithResponse", 1)
+	m.AssertCalled(t, "UpdateSceneWithResponse", mock.Anything, "scene-1", mock.MatchedBy(func(body ScenePut) bool {
+		return *body.Speed == 0.3 && body.Recall == nil
+	}), mock.Anything)
+}
+
+func TestMatchSceneState(t *testing.T) {
+	scene := fromJSON[SceneGet](t, `{
+		"id": "scene-1",
+		"actions": [
+			{"target": {"rid": "light-1"}, "action": {"on": {"on": true}, "dimming": {"brightness": 50}, "color_temperature": {"mirek": 300}}},
+			{"target": {"rid": "light-2"}, "action": {"on": {"on": true}, "dimming": {"brightness": 80}, "color": {"xy": {"x": 0.3, "y": 0.3}}}},
+			{"target": {"rid": "light-3"}, "action": {"on": {"on": false}}},
+			{"target": {"rid": "light-4"}, "action": {"on": {"on": true}}}
+		]
+	}`)
+
+	lights := map[string]LightGet{
+		"light-1": fromJSON[LightGet](t, `{"on": {"on": true}, "dimming": {"brightness": 51}, "color_temperature": {"mirek": 303, "mirek_valid": true}}`),
+		"light-2": fromJSON[LightGet](t, `{"on": {"on": true}, "dimming": {"brightness": 20}, "color": {"xy": {"x": 0.305, "y": 0.3}}}`),
+		"light-3": fromJSON[LightGet](t, `{"on": {"on": false}, "dimming": {"brightness": 20}}`),
+	}
+
+	match := MatchSceneState(scene, lights, DefaultSceneMatchTolerance)
+	assert.Equal(t, "scene-1", match.SceneId)
+	assert.Len(t, match.Lights, 4)
+	assert.False(t, match.IsApplied())
+
+	assert.True(t, match.Lights[0].Matched)
+	assert.False(t, match.Lights[1].Matched)
+	assert.Equal(t, []string{"brightness"}, match.Lights[1].Mismatches)
+	assert.InDelta(t, 2.0/3.0, match.Lights[1].Score, 0.001)
+	assert.True(t, match.Lights[2].Matched)
+	assert.Equal(t, []string{"light"}, match.Lights[3].Mismatches)
+	assert.InDelta(t, (1+2.0/3.0+1+0)/4, match.Score, 0.001)
+}
+
+func TestMatchScene_Applied(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetSceneWithResponse", mock.Anything, "scene-1", mock.Anything).Return(dataResponse[*GetSceneResponse](t, `[
+		{"id": "scene-1", "actions": [{"target": {"rid": "light-1"}, "action": {"on": {"on": true}, "effects": {"effect": "candle"}}}]}
+	]`), nil)
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[
+		{"id": "light-1", "on": {"on": true}, "effects": {"status": "candle"}}
+	]`), nil)
+
+	match, err := home.MatchScene(context.Background(), "scene-1", DefaultSceneMatchTolerance)
+	assert.NoError(t, err)
+	assert.True(t, match.IsApplied())
+	assert.Equal(t, 1.0, match.Score)
+}
+
+func TestCloneScene(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetSceneWithResponse", mock.Anything, "scene-1", mock.Anything).Return(dataResponse[*GetSceneResponse](t, `[{
+		"id": "scene-1",
+		"metadata": {"name": "Focus", "appdata": "app"},
+		"speed": 0.4,
+		"actions": [
+			{"target": {"rid": "light-1"}, "action": {"on": {"on": true}, "color_temperature": {"mirek": 250}}},
+			{"target": {"rid": "light-2"}, "action": {"on": {"on": true}, "color_temperature": {"mirek": 400}}}
+		]
+	}]`), nil)
+	m.On("GetZoneWithResponse", mock.Anything, "zone-1", mock.Anything).Return(dataResponse[*GetZoneResponse](t, `[{"id": "zone-1", "children": [
+		{"rid": "light-3", "rtype": "light"},
+		{"rid": "light-4", "rtype": "light"},
+		{"rid": "light-5", "rtype": "light"},
+		{"rid": "light-6", "rtype": "light"}
+	]}]`), nil)
+
+	var body ScenePost
+	m.On("CreateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { body = args.Get(1).(ScenePost) }).
+		Return(dataResponse[*CreateSceneResponse](t, `[{"rid": "scene-2", "rtype": "scene"}]`), nil)
+
+	target := ResourceIdentifier{Rid: ptr("zone-1"), Rtype: ptr(ResourceIdentifierRtypeZone)}
+	clone, err := home.CloneScene(context.Background(), "scene-1", target, WithCloneName("Focus (copy)"))
+	assert.NoError(t, err)
+	assert.Equal(t, "scene-2", *clone.Rid)
+
+	assert.Equal(t, "Focus (copy)", *body.Metadata.Name)
+	assert.Equal(t, "app", *body.Metadata.Appdata)
+	assert.Equal(t, float32(0.4), *body.Speed)
+	assert.Equal(t, "zone-1", *body.Group.Rid)
+
+	var mireks []int
+	for _, a := range body.Actions {
+		mireks = append(mireks, *a.Action.ColorTemperature.Mirek)
+	}
+	assert.Equal(t, []int{250, 250, 400, 400}, mireks)
+	assert.Equal(t, "light-6", *body.Actions[3].Target.Rid)
+}
+
+func TestMapSceneActions_ByArchetype(t *testing.T) {
+	source := []ActionGet{
+		fromJSON[ActionGet](t, `{"target": {"rid": "spot-1"}, "action": {"dimming": {"brightness": 100}}}`),
+		fromJSON[ActionGet](t, `{"target": {"rid": "lamp-1"}, "action": {"dimming": {"brightness": 30}}}`),
+	}
+	lights := map[string]LightGet{
+		"spot-1": fromJSON[LightGet](t, `{"metadata": {"archetype": "recessed_ceiling"}}`),
+		"lamp-1": fromJSON[LightGet](t, `{"metadata": {"archetype": "table_shade"}}`),
+		"lamp-2": fromJSON[LightGet](t, `{"metadata": {"archetype": "table_shade"}}`),
+		"spot-2": fromJSON[LightGet](t, `{"metadata": {"archetype": "recessed_ceiling"}}`),
+	}
+
+	actions := mapSceneActions(source, []string{"lamp-2", "spot-2"}, MapByArchetype, lights)
+	assert.Equal(t, float32(30), *actions[0].Action.Dimming.Brightness)
+	assert.Equal(t, float32(100), *actions[1].Action.Dimming.Brightness)
+}
+
+const twoLightsScene = `{
+	"id": "scene-1",
+	"actions": [
+		{"target": {"rid": "light-1", "rtype": "light"}, "action": {"on": {"on": true}, "dimming": {"brightness": 50}}},
+		{"target": {"rid": "light-2", "rtype": "light"}, "action": {"on": {"on": false}}}
+	]
+}`
+
+func TestSetSceneAction(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetSceneWithResponse", mock.Anything, "scene-1", mock.Anything).Return(dataResponse[*GetSceneResponse](t, "["+twoLightsScene+"]"), nil)
+
+	var body ScenePut
+	m.On("UpdateSceneWithResponse", mock.Anything, "scene-1", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { body = args.Get(2).(ScenePut) }).
+		Return(&UpdateSceneResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	action := ActionPost{Target: ResourceIdentifier{Rid: ptr("light-2"), Rtype: ptr(ResourceIdentifierRtypeLight)}}
+	action.Action.Dimming = &Dimming{Brightness: ptr(float32(75))}
+
+	err := home.SetSceneAction(context.Background(), "scene-1", action)
+	assert.NoError(t, err)
+
+	assert.Len(t, *body.Actions, 2)
+	assert.Equal(t, float32(50), *(*body.Actions)[0].Action.Dimming.Brightness)
+	assert.Equal(t, float32(75), *(*body.Actions)[1].Action.Dimming.Brightness)
+}
+
+func TestAddSceneLight_Conflict(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetSceneWithResponse", mock.Anything, "scene-1", mock.Anything).Return(dataResponse[*GetSceneResponse](t, "["+twoLightsScene+"]"), nil)
+
+	action := ActionPost{Target: ResourceIdentifier{Rid: ptr("light-1"), Rtype: ptr(ResourceIdentifierRtypeLight)}}
+	err := home.AddSceneLight(context.Background(), "scene-1", action)
+	assert.True(t, errors.Is(err, ErrConflict))
+	m.AssertNotCalled(t, "UpdateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveSceneLight(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetSceneWithResponse", mock.Anything, "scene-1", mock.Anything).Return(dataResponse[*GetSceneResponse](t, "["+twoLightsScene+"]"), nil)
+
+	var body ScenePut
+	m.On("UpdateSceneWithResponse", mock.Anything, "scene-1", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { body = args.Get(2).(ScenePut) }).
+		Return(&UpdateSceneResponse{HTTPResponse: &http.Response{StatusCode: http.StatusOK}}, nil)
+
+	err := home.RemoveSceneLight(context.Background(), "scene-1", "light-1")
+	assert.NoError(t, err)
+	assert.Len(t, *body.Actions, 1)
+	assert.Equal(t, "light-2", *(*body.Actions)[0].Target.Rid)
+
+	err = home.RemoveSceneLight(context.Background(), "scene-1", "light-3")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestRemoveSceneLight_ConcurrentModification(t *testing.T) {
+	home, m := NewTestHome()
+
+	modified := dataResponse[*GetSceneResponse](t, "["+twoLightsScene+"]")
+	(*(*modified.JSON200.Data)[0].Actions)[0].Action.Dimming.Brightness = ptr(float32(10))
+
+	m.On("GetSceneWithResponse", mock.Anything, "scene-1", mock.Anything).Return(dataResponse[*GetSceneResponse](t, "["+twoLightsScene+"]"), nil).Once()
+	m.On("GetSceneWithResponse", mock.Anything, "scene-1", mock.Anything).Return(modified, nil).Once()
+
+	err := home.RemoveSceneLight(context.Background(), "scene-1", "light-2")
+	assert.True(t, errors.Is(err, ErrSceneModified))
+	m.AssertNotCalled(t, "UpdateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
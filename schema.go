@@ -0,0 +1,301 @@
+package openhue
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// JSON SCHEMA
+//--------------------------------------------------------------------------------------------------------------------//
+
+// validateSchema checks a value against a JSON schema and returns the violations found, prefixed by their path.
+// Only the subset of the JSON schema specification used by the behavior scripts of the bridge is supported: type,
+// enum, const, properties, required, additionalProperties, items, the numeric, string and array bounds, pattern,
+// allOf, anyOf, oneOf and local $ref. Other keywords are ignored.
+func validateSchema(schema map[string]any, value any) []string {
+	v := &schemaValidator{root: schema, resolving: make(map[string]bool)}
+	v.validate(schema, value, "")
+	return v.violations
+}
+
+// toJSONValue converts a value to its generic JSON representation, as returned by json.Unmarshal into an any.
+func toJSONValue(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+type schemaValidator struct {
+	root map[string]any
+	// resolving holds the references being resolved, by path, to detect references that point at themselves or at
+	// one of their ancestors without going deeper into the value.
+	resolving  map[string]bool
+	violations []string
+}
+
+func (v *schemaValidator) fail(path string, format string, args ...any) {
+	if path == "" {
+		path = "(root)"
+	}
+	v.violations = append(v.violations, path+": "+fmt.Sprintf(format, args...))
+}
+
+// matches returns true if the value is valid against the schema, without recording any violation.
+func (v *schemaValidator) matches(schema map[string]any, value any, path string) bool {
+	sub := &schemaValidator{root: v.root, resolving: v.resolving}
+	sub.validate(schema, value, path)
+	return len(sub.violations) == 0
+}
+
+func (v *schemaValidator) validate(schema map[string]any, value any, path string) {
+
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, err := v.resolve(ref)
+		if err != nil {
+			v.fail(path, "%v", err)
+			return
+		}
+		key := path + " " + ref
+		if v.resolving[key] {
+			v.fail(path, "circular schema reference %q", ref)
+			return
+		}
+		v.resolving[key] = true
+		v.validate(resolved, value, path)
+		delete(v.resolving, key)
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, value) {
+		v.fail(path, "expected %s, got %s", formatTypes(t), jsonType(value))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !containsValue(enum, value) {
+		v.fail(path, "%s is not one of %s", formatValue(value), formatValue(enum))
+	}
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		v.fail(path, "expected %s, got %s", formatValue(c), formatValue(value))
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		v.validateObject(schema, value, path)
+	case []any:
+		v.validateArray(schema, value, path)
+	case string:
+		v.validateString(schema, value, path)
+	case float64:
+		v.validateNumber(schema, value, path)
+	}
+
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, s := range all {
+			if sub, ok := s.(map[string]any); ok {
+				v.validate(sub, value, path)
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok && v.countMatches(anyOf, value, path) == 0 {
+		v.fail(path, "does not match any of the allowed schemas")
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		if n := v.countMatches(oneOf, value, path); n != 1 {
+			v.fail(path, "matches %d of the allowed schemas instead of exactly one", n)
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(schema map[string]any, value map[string]any, path string) {
+
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			if name, ok := r.(string); ok {
+				if _, present := value[name]; !present {
+					v.fail(joinPath(path, name), "is required")
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	for _, name := range sortedIds(value) {
+		if p, ok := properties[name].(map[string]any); ok {
+			v.validate(p, value[name], joinPath(path, name))
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(joinPath(path, name), "is not allowed")
+			}
+		case map[string]any:
+			v.validate(additional, value[name], joinPath(path, name))
+		}
+	}
+}
+
+func (v *schemaValidator) validateArray(schema map[string]any, value []any, path string) {
+
+	if min, ok := schema["minItems"].(float64); ok && float64(len(value)) < min {
+		v.fail(path, "has %d items, expected at least %v", len(value), min)
+	}
+	if max, ok := schema["maxItems"].(float64); ok && float64(len(value)) > max {
+		v.fail(path, "has %d items, expected at most %v", len(value), max)
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		for i, item := range value {
+			v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(schema map[string]any, value string, path string) {
+
+	length := len([]rune(value))
+	if min, ok := schema["minLength"].(float64); ok && float64(length) < min {
+		v.fail(path, "is shorter than %v characters", min)
+	}
+	if max, ok := schema["maxLength"].(float64); ok && float64(length) > max {
+		v.fail(path, "is longer than %v characters", max)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err == nil && !re.MatchString(value) {
+			v.fail(path, "%q does not match %q", value, pattern)
+		}
+	}
+}
+
+func (v *schemaValidator) validateNumber(schema map[string]any, value float64, path string) {
+
+	if min, ok := schema["minimum"].(float64); ok && value < min {
+		v.fail(path, "%v is lower than %v", value, min)
+	}
+	if max, ok := schema["maximum"].(float64); ok && value > max {
+		v.fail(path, "%v is greater than %v", value, max)
+	}
+	if min, ok := schema["exclusiveMinimum"].(float64); ok && value <= min {
+		v.fail(path, "%v is not greater than %v", value, min)
+	}
+	if max, ok := schema["exclusiveMaximum"].(float64); ok && value >= max {
+		v.fail(path, "%v is not lower than %v", value, max)
+	}
+}
+
+func (v *schemaValidator) countMatches(schemas []any, value any, path string) int {
+	n := 0
+	for _, s := range schemas {
+		if sub, ok := s.(map[string]any); ok && v.matches(sub, value, path) {
+			n++
+		}
+	}
+	return n
+}
+
+// resolve returns the schema a local reference such as "#/definitions/time" points at.
+func (v *schemaValidator) resolve(ref string) (map[string]any, error) {
+
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported schema reference %q", ref)
+	}
+
+	var current any = v.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolved schema reference %q", ref)
+		}
+		if current, ok = m[part]; !ok {
+			return nil, fmt.Errorf("unresolved schema reference %q", ref)
+		}
+	}
+
+	schema, ok := current.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unresolved schema reference %q", ref)
+	}
+	return schema, nil
+}
+
+func matchesType(t any, value any) bool {
+	switch t := t.(type) {
+	case string:
+		return isType(t, value)
+	case []any:
+		for _, candidate := range t {
+			if s, ok := candidate.(string); ok && isType(s, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func isType(t string, value any) bool {
+	switch t {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonType(value) == t
+	}
+}
+
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func formatTypes(t any) string {
+	if types, ok := t.([]any); ok {
+		names := make([]string, 0, len(types))
+		for _, name := range types {
+			names = append(names, fmt.Sprint(name))
+		}
+		sort.Strings(names)
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
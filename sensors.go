@@ -0,0 +1,124 @@
+package openhue
+
+import (
+	"math"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// LIGHT LEVEL SENSOR
+//--------------------------------------------------------------------------------------------------------------------//
+
+// Lux returns the illuminance measured by the sensor, and false if it is unknown or not valid.
+func (l *LightLevelGet) Lux() (float64, bool) {
+	if l.Light == nil || (l.Light.LightLevelValid != nil && !*l.Light.LightLevelValid) {
+		return 0, false
+	}
+
+	level := l.Light.LightLevel
+	if l.Light.LightLevelReport != nil && l.Light.LightLevelReport.LightLevel != nil {
+		level = l.Light.LightLevelReport.LightLevel
+	}
+	if level == nil {
+		return 0, false
+	}
+
+	return LightLevelToLux(*level), true
+}
+
+// IsDark returns true when the illuminance is below the threshold, in lux. It returns false if the illuminance is
+// unknown or not valid.
+func (l *LightLevelGet) IsDark(threshold float64) bool {
+	lux, ok := l.Lux()
+	return ok && lux < threshold
+}
+
+// Changed returns the last time the light level changed, the zero time if it is unknown.
+func (l *LightLevelGet) Changed() time.Time {
+	if l.Light == nil || l.Light.LightLevelReport == nil || l.Light.LightLevelReport.Changed == nil {
+		return time.Time{}
+	}
+	return *l.Light.LightLevelReport.Changed
+}
+
+// LightLevelToLux converts a light level, as reported by the bridge in 10000*log10(lux)+1, to lux. Level 0 is total
+// darkness, 0 lux.
+func LightLevelToLux(level int) float64 {
+	if level <= 0 {
+		return 0
+	}
+	return math.Pow(10, float64(level-1)/10000)
+}
+
+// LuxToLightLevel converts an illuminance in lux to the light level scale of the bridge, 10000*log10(lux)+1.
+func LuxToLightLevel(lux float64) int {
+	if lux <= 0 {
+		return 0
+	}
+	return int(math.Round(10000*math.Log10(lux))) + 1
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// CONTACT SENSOR
+//--------------------------------------------------------------------------------------------------------------------//
+
+// IsOpen returns true when the contact sensor reports no contact, e.g. an open door or window.
+func (c *ContactGet) IsOpen() bool {
+	return c.ContactReport != nil && c.ContactReport.State != nil && *c.ContactReport.State == ContactGetContactReportStateNoContact
+}
+
+// Changed returns the last time the contact state changed, the zero time if it is unknown.
+func (c *ContactGet) Changed() time.Time {
+	if c.ContactReport == nil || c.ContactReport.Changed == nil {
+		return time.Time{}
+	}
+	return *c.ContactReport.Changed
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// TAMPER SENSOR
+//--------------------------------------------------------------------------------------------------------------------//
+
+// IsTampered returns true when any source of the sensor reports tampering, e.g. a removed battery cover.
+func (t *TamperGet) IsTampered() bool {
+	if t.TamperReports == nil {
+		return false
+	}
+	for _, report := range *t.TamperReports {
+		if report.State != nil && *report.State == Tampered {
+			return true
+		}
+	}
+	return false
+}
+
+// Changed returns the last time the tamper state of any source changed, the zero time if it is unknown.
+func (t *TamperGet) Changed() time.Time {
+	var changed time.Time
+	if t.TamperReports == nil {
+		return changed
+	}
+	for _, report := range *t.TamperReports {
+		if report.Changed != nil && report.Changed.After(changed) {
+			changed = *report.Changed
+		}
+	}
+	return changed
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// CAMERA MOTION SENSOR
+//--------------------------------------------------------------------------------------------------------------------//
+
+// IsMotion returns true when the camera reports motion.
+func (c *CameraMotionGet) IsMotion() bool {
+	return c.Motion != nil && c.Motion.MotionReport != nil && c.Motion.MotionReport.Motion != nil && *c.Motion.MotionReport.Motion
+}
+
+// Changed returns the last time the motion state changed, the zero time if it is unknown.
+func (c *CameraMotionGet) Changed() time.Time {
+	if c.Motion == nil || c.Motion.MotionReport == nil || c.Motion.MotionReport.Changed == nil {
+		return time.Time{}
+	}
+	return *c.Motion.MotionReport.Changed
+}
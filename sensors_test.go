@@ -0,0 +1,149 @@
+package openhue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetContactSensors(t *testing.T) {
+	home, m := NewTestHome()
+
+	contacts := []ContactGet{
+		fromJSON[ContactGet](t, `{"id": "contact-1", "contact_report": {"state": "no_contact", "changed": "2026-03-01T10:00:00Z"}}`),
+	}
+	resp := GetContactsResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200: &struct {
+			Data   *[]ContactGet `json:"data,omitempty"`
+			Errors *[]Error      `json:"errors,omitempty"`
+		}{Data: &contacts},
+	}
+	m.On("GetContactsWithResponse", mock.Anything, mock.Anything).Return(&resp, nil)
+
+	sensors, err := home.GetContactSensors(context.Background())
+	assert.NoError(t, err)
+
+	contact := sensors["contact-1"]
+	assert.True(t, contact.IsOpen())
+	assert.Equal(t, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), contact.Changed())
+}
+
+func TestGetTamperSensorById_EmptyResponse(t *testing.T) {
+	home, m := NewTestHome()
+
+	emptyData := []TamperGet{}
+	resp := GetTamperResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200: &struct {
+			Data   *[]TamperGet `json:"data,omitempty"`
+			Errors *[]Error     `json:"errors,omitempty"`
+		}{Data: &emptyData},
+	}
+	m.On("GetTamperWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(&resp, nil)
+
+	_, err := home.GetTamperSensorById(context.Background(), "some-id")
+	assert.True(t, errors.Is(err, ErrEmptyResponse))
+}
+
+func TestUpdateCameraMotionSensor_Forbidden(t *testing.T) {
+	home, m := NewTestHome()
+
+	resp := UpdateCameraMotionResponse{
+		HTTPResponse: &http.Response{StatusCode: 403},
+	}
+	m.On("UpdateCameraMotionWithResponse", mock.Anything, "camera-1", mock.Anything, mock.Anything).Return(&resp, nil)
+
+	err := home.UpdateCameraMotionSensor(context.Background(), "camera-1", CameraMotionPut{Enabled: ptr(false)})
+	assert.True(t, errors.Is(err, ErrForbidden))
+}
+
+func TestContactGet_IsOpen(t *testing.T) {
+	assert.False(t, (&ContactGet{}).IsOpen())
+	assert.True(t, (&ContactGet{}).Changed().IsZero())
+
+	closed := fromJSON[ContactGet](t, `{"contact_report": {"state": "contact"}}`)
+	assert.False(t, closed.IsOpen())
+}
+
+func TestTamperGet_IsTampered(t *testing.T) {
+	assert.False(t, (&TamperGet{}).IsTampered())
+
+	tamper := fromJSON[TamperGet](t, `{"tamper_reports": [
+		{"source": "battery_door", "state": "not_tampered", "changed": "2026-03-01T10:00:00Z"},
+		{"source": "battery_door", "state": "tampered", "changed": "2026-03-02T10:00:00Z"}
+	]}`)
+	assert.True(t, tamper.IsTampered())
+	assert.Equal(t, time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), tamper.Changed())
+}
+
+func TestCameraMotionGet_IsMotion(t *testing.T) {
+	assert.False(t, (&CameraMotionGet{}).IsMotion())
+
+	camera := fromJSON[CameraMotionGet](t, `{"motion": {"motion_report": {"motion": true, "changed": "2026-03-01T10:00:00Z"}}}`)
+	assert.True(t, camera.IsMotion())
+	assert.Equal(t, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), camera.Changed())
+}
+
+func TestGetLightLevelSensors(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetLightLevelsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightLevelsResponse](t, `[
+		{"id": "level-1", "light": {"light_level_report": {"light_level": 10001, "changed": "2026-03-01T10:00:00Z"}}}
+	]`), nil)
+
+	sensors, err := home.GetLightLevelSensors(context.Background())
+	assert.NoError(t, err)
+
+	sensor := sensors["level-1"]
+	lux, ok := sensor.Lux()
+	assert.True(t, ok)
+	assert.InDelta(t, 10, lux, 0.001)
+	assert.True(t, sensor.IsDark(50))
+	assert.False(t, sensor.IsDark(5))
+	assert.Equal(t, time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC), sensor.Changed())
+}
+
+func TestUpdateLightLevelSensor(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("UpdateLightLevelWithResponse", mock.Anything, "level-1", LightLevelPut{Enabled: ptr(false)}, mock.Anything).Return(&UpdateLightLevelResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+	}, nil)
+
+	err := home.UpdateLightLevelSensor(context.Background(), "level-1", LightLevelPut{Enabled: ptr(false)})
+	assert.NoError(t, err)
+}
+
+func TestLightLevelGet_Lux(t *testing.T) {
+	_, ok := (&LightLevelGet{}).Lux()
+	assert.False(t, ok)
+
+	invalid := fromJSON[LightLevelGet](t, `{"light": {"light_level": 10001, "light_level_valid": false}}`)
+	_, ok = invalid.Lux()
+	assert.False(t, ok)
+	assert.False(t, invalid.IsDark(1000))
+
+	deprecated := fromJSON[LightLevelGet](t, `{"light": {"light_level": 1, "light_level_valid": true}}`)
+	lux, ok := deprecated.Lux()
+	assert.True(t, ok)
+	assert.InDelta(t, 1, lux, 0.001)
+}
+
+func TestLuxToLightLevel(t *testing.T) {
+	assert.Equal(t, 10001, LuxToLightLevel(10))
+	assert.Equal(t, 0, LuxToLightLevel(0))
+	assert.Equal(t, 25000, LuxToLightLevel(LightLevelToLux(25000)))
+}
+
+func TestLightLevelToLux_Darkness(t *testing.T) {
+	assert.Equal(t, 0.0, LightLevelToLux(0))
+	assert.Equal(t, 0.0, LightLevelToLux(-1))
+	assert.Equal(t, 0, LuxToLightLevel(LightLevelToLux(0)))
+	assert.Equal(t, 0.0, LightLevelToLux(LuxToLightLevel(0)))
+}
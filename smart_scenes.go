@@ -0,0 +1,295 @@
+package openhue
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SMART SCENE SCHEDULE
+//--------------------------------------------------------------------------------------------------------------------//
+
+var (
+	// Weekdays contains the days from Monday to Friday.
+	Weekdays = []Weekday{Monday, Tuesday, Wednesday, Thursday, Friday}
+	// Weekend contains Saturday and Sunday.
+	Weekend = []Weekday{Saturday, Sunday}
+	// EveryDay contains all the days of the week.
+	EveryDay = []Weekday{Monday, Tuesday, Wednesday, Thursday, Friday, Saturday, Sunday}
+)
+
+// SmartSceneScheduleBuilder builds the weekly timeslots of a smart scene.
+// Errors are collected while building and returned by Build, so that calls can be chained.
+//
+// Example:
+//
+//	timeslots, err := openhue.NewSmartSceneSchedule().
+//		On(openhue.Weekdays...).
+//		At(7, 0, energizeSceneId).
+//		AtSunset(relaxSceneId).
+//		At(22, 30, nightlightSceneId).
+//		On(openhue.Weekend...).
+//		At(9, 0, brightSceneId).
+//		AtSunset(relaxSceneId).
+//		Build()
+type SmartSceneScheduleBuilder struct {
+	days []DayTimeslotsGet
+	errs []error
+}
+
+// NewSmartSceneSchedule creates an empty SmartSceneScheduleBuilder.
+func NewSmartSceneSchedule() *SmartSceneScheduleBuilder {
+	return &SmartSceneScheduleBuilder{}
+}
+
+// On starts a new group of days sharing the same timeslots. The following calls to At and AtSunset add timeslots
+// to this group.
+func (b *SmartSceneScheduleBuilder) On(days ...Weekday) *SmartSceneScheduleBuilder {
+	if len(days) == 0 {
+		b.errs = append(b.errs, errors.New("at least one weekday must be provided"))
+	}
+	b.days = append(b.days, DayTimeslotsGet{Recurrence: append([]Weekday(nil), days...)})
+	return b
+}
+
+// At adds a timeslot recalling the given scene at a fixed time of the day.
+func (b *SmartSceneScheduleBuilder) At(hour, minute int, sceneId string) *SmartSceneScheduleBuilder {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		b.errs = append(b.errs, fmt.Errorf("invalid time %02d:%02d", hour, minute))
+	}
+
+	slot := newSmartSceneTimeslot(SmartSceneTimeslotGetStartTimeKindTime, sceneId)
+	second := 0
+	slot.StartTime.Time = &struct {
+		Hour   *int `json:"hour,omitempty"`
+		Minute *int `json:"minute,omitempty"`
+		Second *int `json:"second,omitempty"`
+	}{Hour: &hour, Minute: &minute, Second: &second}
+
+	return b.addTimeslot(slot)
+}
+
+// AtSunset adds a timeslot recalling the given scene at sunset. The time of the sunset is computed by the bridge
+// from its geolocation.
+func (b *SmartSceneScheduleBuilder) AtSunset(sceneId string) *SmartSceneScheduleBuilder {
+	return b.addTimeslot(newSmartSceneTimeslot(SmartSceneTimeslotGetStartTimeKindSunset, sceneId))
+}
+
+func (b *SmartSceneScheduleBuilder) addTimeslot(slot SmartSceneTimeslotGet) *SmartSceneScheduleBuilder {
+	if len(b.days) == 0 {
+		b.errs = append(b.errs, errors.New("On must be called before adding timeslots"))
+		return b
+	}
+	if *slot.Target.Rid == "" {
+		b.errs = append(b.errs, errors.New("timeslot scene ID cannot be empty"))
+	}
+	day := &b.days[len(b.days)-1]
+	day.Timeslots = append(day.Timeslots, slot)
+	return b
+}
+
+// Build validates the schedule and returns the weekly timeslots to be used in a SmartScenePost or SmartScenePut.
+func (b *SmartSceneScheduleBuilder) Build() ([]DayTimeslotsGet, error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+	if err := ValidateSmartSceneSchedule(b.days); err != nil {
+		return nil, err
+	}
+	return b.days, nil
+}
+
+// ValidateSmartSceneSchedule checks that the weekly timeslots of a smart scene are consistent:
+// each group of days has at least one timeslot, a weekday belongs to at most one group, fixed time timeslots are
+// strictly ordered and there is at most one sunset timeslot per day.
+func ValidateSmartSceneSchedule(week []DayTimeslotsGet) error {
+
+	if len(week) == 0 {
+		return errors.New("smart scene schedule must contain at least one group of days")
+	}
+
+	seen := make(map[Weekday]bool)
+	for i, day := range week {
+		if len(day.Recurrence) == 0 {
+			return fmt.Errorf("days group %d has no weekday", i)
+		}
+		for _, d := range day.Recurrence {
+			if _, ok := weekdayIndex[d]; !ok {
+				return fmt.Errorf("days group %d has an invalid weekday '%s'", i, d)
+			}
+			if seen[d] {
+				return fmt.Errorf("weekday '%s' is scheduled more than once", d)
+			}
+			seen[d] = true
+		}
+
+		if len(day.Timeslots) == 0 {
+			return fmt.Errorf("days group %d has no timeslot", i)
+		}
+
+		previous := -1
+		sunset := false
+		for j, slot := range day.Timeslots {
+			switch slot.StartTime.Kind {
+			case SmartSceneTimeslotGetStartTimeKindSunset:
+				if sunset {
+					return fmt.Errorf("days group %d has more than one sunset timeslot", i)
+				}
+				sunset = true
+			case SmartSceneTimeslotGetStartTimeKindTime:
+				offset, err := timeslotOffset(slot)
+				if err != nil {
+					return fmt.Errorf("days group %d, timeslot %d: %w", i, j, err)
+				}
+				if offset <= previous {
+					return fmt.Errorf("days group %d, timeslot %d: timeslots must be ordered by start time", i, j)
+				}
+				previous = offset
+			default:
+				return fmt.Errorf("days group %d, timeslot %d: unsupported start time kind '%s'", i, j, slot.StartTime.Kind)
+			}
+		}
+	}
+
+	return nil
+}
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SMART SCENE EVALUATION
+//--------------------------------------------------------------------------------------------------------------------//
+
+// SunsetFunc returns the time of the sunset on the day of the given time.
+type SunsetFunc func(day time.Time) time.Time
+
+// SmartSceneTimeslot is the timeslot a smart scene selects at a given time.
+type SmartSceneTimeslot struct {
+	// Weekday is the day of the week the timeslot belongs to.
+	Weekday Weekday
+	// TimeslotId is the index of the timeslot within the timeslots of its day, as reported by the bridge.
+	TimeslotId int
+	// Start is the time at which the timeslot started.
+	Start time.Time
+	// Target is the scene recalled by the timeslot.
+	Target ResourceIdentifier
+}
+
+// Evaluate returns the timeslot that the smart scene selects at the given time.
+// See EvaluateSmartSceneSchedule for details.
+func (s *SmartSceneGet) Evaluate(at time.Time, sunset SunsetFunc) (*SmartSceneTimeslot, error) {
+	return EvaluateSmartSceneSchedule(s.WeekTimeslots, at, sunset)
+}
+
+// EvaluateSmartSceneSchedule returns the timeslot of the weekly schedule that is active at the given time, without
+// calling the bridge. When the time is before the first timeslot of the day, the last timeslot of the previous
+// scheduled day remains active. The sunset function is only required when the schedule has sunset timeslots.
+func EvaluateSmartSceneSchedule(week []DayTimeslotsGet, at time.Time, sunset SunsetFunc) (*SmartSceneTimeslot, error) {
+
+	for daysBack := 0; daysBack <= 7; daysBack++ {
+		day := at.AddDate(0, 0, -daysBack)
+		weekday := toWeekday(day.Weekday())
+
+		slots, ok := findDayTimeslots(week, weekday)
+		if !ok {
+			continue
+		}
+
+		var active *SmartSceneTimeslot
+		for i, slot := range slots {
+			start, err := timeslotStart(slot, day, sunset)
+			if err != nil {
+				return nil, err
+			}
+			if start.After(at) {
+				continue
+			}
+			if active == nil || !start.Before(active.Start) {
+				active = &SmartSceneTimeslot{Weekday: weekday, TimeslotId: i, Start: start, Target: slot.Target}
+			}
+		}
+
+		if active != nil {
+			return active, nil
+		}
+	}
+
+	return nil, errors.New("no timeslot found in the smart scene schedule")
+}
+
+func findDayTimeslots(week []DayTimeslotsGet, weekday Weekday) ([]SmartSceneTimeslotGet, bool) {
+	for _, day := range week {
+		for _, d := range day.Recurrence {
+			if d == weekday {
+				return day.Timeslots, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func timeslotStart(slot SmartSceneTimeslotGet, day time.Time, sunset SunsetFunc) (time.Time, error) {
+	switch slot.StartTime.Kind {
+	case SmartSceneTimeslotGetStartTimeKindSunset:
+		if sunset == nil {
+			return time.Time{}, errors.New("a sunset function is required to evaluate sunset timeslots")
+		}
+		return sunset(day), nil
+	case SmartSceneTimeslotGetStartTimeKindTime:
+		offset, err := timeslotOffset(slot)
+		if err != nil {
+			return time.Time{}, err
+		}
+		// wall clock time, which differs from the time elapsed since midnight on daylight saving time changes
+		return time.Date(day.Year(), day.Month(), day.Day(), offset/3600, offset%3600/60, offset%60, 0, day.Location()), nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported start time kind '%s'", slot.StartTime.Kind)
+	}
+}
+
+// timeslotOffset returns the number of seconds between midnight and the start of a fixed time timeslot.
+func timeslotOffset(slot SmartSceneTimeslotGet) (int, error) {
+	t := slot.StartTime.Time
+	if t == nil || t.Hour == nil {
+		return 0, errors.New("time must be set for timeslots of kind time")
+	}
+
+	hour, minute, second := *t.Hour, 0, 0
+	if t.Minute != nil {
+		minute = *t.Minute
+	}
+	if t.Second != nil {
+		second = *t.Second
+	}
+
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 || second < 0 || second > 59 {
+		return 0, fmt.Errorf("invalid time %02d:%02d:%02d", hour, minute, second)
+	}
+
+	return hour*3600 + minute*60 + second, nil
+}
+
+func newSmartSceneTimeslot(kind SmartSceneTimeslotGetStartTimeKind, sceneId string) SmartSceneTimeslotGet {
+	rtype := ResourceIdentifierRtypeScene
+	slot := SmartSceneTimeslotGet{Target: ResourceIdentifier{Rid: &sceneId, Rtype: &rtype}}
+	slot.StartTime.Kind = kind
+	return slot
+}
+
+var weekdayIndex = map[Weekday]time.Weekday{
+	Sunday:    time.Sunday,
+	Monday:    time.Monday,
+	Tuesday:   time.Tuesday,
+	Wednesday: time.Wednesday,
+	Thursday:  time.Thursday,
+	Friday:    time.Friday,
+	Saturday:  time.Saturday,
+}
+
+func toWeekday(d time.Weekday) Weekday {
+	for w, i := range weekdayIndex {
+		if i == d {
+			return w
+		}
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+package openhue
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSmartSceneSchedule_Build(t *testing.T) {
+	week, err := NewSmartSceneSchedule().
+		On(Weekdays...).
+		At(7, 0, "energize").
+		AtSunset("relax").
+		At(22, 30, "nightlight").
+		On(Weekend...).
+		At(9, 0, "bright").
+		Build()
+
+	assert.NoError(t, err)
+	assert.Len(t, week, 2)
+	assert.Equal(t, Weekdays, week[0].Recurrence)
+	assert.Len(t, week[0].Timeslots, 3)
+	assert.Equal(t, SmartSceneTimeslotGetStartTimeKindSunset, week[0].Timeslots[1].StartTime.Kind)
+	assert.Equal(t, 22, *week[0].Timeslots[2].StartTime.Time.Hour)
+	assert.Equal(t, "bright", *week[1].Timeslots[0].Target.Rid)
+}
+
+func TestSmartSceneSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *SmartSceneScheduleBuilder
+		wantErr string
+	}{
+		{"overlapping weekdays", NewSmartSceneSchedule().On(Monday, Tuesday).At(7, 0, "a").On(Tuesday).At(8, 0, "b"), "weekday 'tuesday' is scheduled more than once"},
+		{"unordered timeslots", NewSmartSceneSchedule().On(Monday).At(9, 0, "a").At(8, 0, "b"), "timeslots must be ordered"},
+		{"invalid time", NewSmartSceneSchedule().On(Monday).At(25, 0, "a"), "invalid time 25:00"},
+		{"timeslot before days", NewSmartSceneSchedule().At(8, 0, "a"), "On must be called before adding timeslots"},
+		{"no timeslot", NewSmartSceneSchedule().On(Monday), "has no timeslot"},
+		{"two sunsets", NewSmartSceneSchedule().On(Monday).AtSunset("a").AtSunset("b"), "more than one sunset timeslot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEvaluateSmartSceneSchedule(t *testing.T) {
+	week, err := NewSmartSceneSchedule().
+		On(Weekdays...).
+		At(7, 0, "energize").
+		AtSunset("relax").
+		At(22, 30, "nightlight").
+		On(Saturday).
+		At(10, 0, "bright").
+		Build()
+	assert.NoError(t, err)
+
+	sunset := func(day time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), 19, 15, 0, 0, day.Location())
+	}
+
+	// 2024-06-03 is a Monday
+	tests := []struct {
+		name       string
+		at         time.Time
+		wantScene  string
+		wantDay    Weekday
+		wantSlotId int
+	}{
+		{"morning", time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC), "energize", Monday, 0},
+		{"after sunset", time.Date(2024, 6, 3, 20, 0, 0, 0, time.UTC), "relax", Monday, 1},
+		{"late evening", time.Date(2024, 6, 3, 23, 0, 0, 0, time.UTC), "nightlight", Monday, 2},
+		{"before first timeslot", time.Date(2024, 6, 4, 6, 0, 0, 0, time.UTC), "nightlight", Monday, 2},
+		{"saturday", time.Date(2024, 6, 8, 12, 0, 0, 0, time.UTC), "bright", Saturday, 0},
+		{"unscheduled sunday", time.Date(2024, 6, 9, 12, 0, 0, 0, time.UTC), "bright", Saturday, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, err := EvaluateSmartSceneSchedule(week, tt.at, sunset)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantScene, *slot.Target.Rid)
+			assert.Equal(t, tt.wantDay, slot.Weekday)
+			assert.Equal(t, tt.wantSlotId, slot.TimeslotId)
+		})
+	}
+}
+
+func TestEvaluateSmartSceneSchedule_DaylightSavingTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+
+	week, err := NewSmartSceneSchedule().On(EveryDay...).At(7, 0, "energize").At(22, 0, "nightlight").Build()
+	assert.NoError(t, err)
+
+	// clocks go forward from 02:00 to 03:00 on 2024-03-31, and back from 03:00 to 02:00 on 2024-10-27
+	for _, day := range []time.Time{
+		time.Date(2024, 3, 31, 0, 0, 0, 0, paris),
+		time.Date(2024, 10, 27, 0, 0, 0, 0, paris),
+	} {
+		at := func(hour, minute int) time.Time {
+			return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, paris)
+		}
+
+		slot, err := EvaluateSmartSceneSchedule(week, at(7, 30), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "energize", *slot.Target.Rid)
+		assert.Equal(t, at(7, 0), slot.Start)
+
+		slot, err = EvaluateSmartSceneSchedule(week, at(6, 30), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "nightlight", *slot.Target.Rid)
+	}
+}
+
+func TestEvaluateSmartSceneSchedule_MissingSunset(t *testing.T) {
+	week, err := NewSmartSceneSchedule().On(EveryDay...).AtSunset("relax").Build()
+	assert.NoError(t, err)
+
+	_, err = EvaluateSmartSceneSchedule(week, time.Now(), nil)
+	assert.ErrorContains(t, err, "sunset function is required")
+}
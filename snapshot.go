@@ -0,0 +1,218 @@
+package openhue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SNAPSHOT
+//--------------------------------------------------------------------------------------------------------------------//
+
+// Snapshot is the complete state of a bridge at a given time. It is serializable to and from JSON, which makes it
+// suitable for bug reports, offline analysis or test fixtures.
+type Snapshot struct {
+	TakenAt time.Time  `json:"taken_at"`
+	Bridge  *BridgeGet `json:"bridge,omitempty"`
+
+	Devices      map[string]DeviceGet      `json:"devices"`
+	DevicePowers map[string]DevicePowerGet `json:"device_powers"`
+
+	Rooms         map[string]RoomGet         `json:"rooms"`
+	Zones         map[string]RoomGet         `json:"zones"`
+	Lights        map[string]LightGet        `json:"lights"`
+	GroupedLights map[string]GroupedLightGet `json:"grouped_lights"`
+
+	Scenes      map[string]SceneGet      `json:"scenes"`
+	SmartScenes map[string]SmartSceneGet `json:"smart_scenes"`
+
+	Motions          map[string]MotionGet         `json:"motions"`
+	Temperatures     map[string]TemperatureGet    `json:"temperatures"`
+	LightLevels      map[string]LightLevelGet     `json:"light_levels"`
+	Contacts         map[string]ContactGet        `json:"contacts"`
+	Tampers          map[string]TamperGet         `json:"tampers"`
+	CameraMotions    map[string]CameraMotionGet   `json:"camera_motions"`
+	Buttons          map[string]ButtonGet         `json:"buttons"`
+	RelativeRotaries map[string]RelativeRotaryGet `json:"relative_rotaries"`
+
+	EntertainmentConfigurations map[string]EntertainmentConfigurationGet `json:"entertainment_configurations"`
+
+	// Other holds the raw JSON of the resources of any other type, indexed by resource type then by ID.
+	Other map[ResourceGetType]map[string]json.RawMessage `json:"other,omitempty"`
+}
+
+// Snapshot fetches the complete state of the bridge with a single call to the resource endpoint.
+func (h *Home) Snapshot(ctx context.Context) (*Snapshot, error) {
+	resp, err := h.api.GetResourcesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.HTTPResponse.StatusCode != http.StatusOK {
+		return nil, newApiError(resp)
+	}
+
+	// The generated ResourceGet type only keeps the fields common to all resources, so the raw body is decoded instead
+	var body struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, fmt.Errorf("unable to decode resources: %w", err)
+	}
+
+	s := newSnapshot()
+	s.TakenAt = time.Now()
+
+	for _, raw := range body.Data {
+		if err := s.add(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// ParseSnapshot decodes a Snapshot from its JSON representation.
+func ParseSnapshot(data []byte) (*Snapshot, error) {
+	s := newSnapshot()
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("unable to parse snapshot: %w", err)
+	}
+	for _, resources := range s.Other {
+		for id, raw := range resources {
+			resources[id] = compactJSON(raw)
+		}
+	}
+	return s, nil
+}
+
+// JSON encodes the snapshot as indented JSON.
+func (s *Snapshot) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// Topology links the resources of the snapshot together, see Home.GetTopology.
+func (s *Snapshot) Topology() *Topology {
+	return newTopology(topologyData{
+		bridge:        s.Bridge,
+		rooms:         s.Rooms,
+		zones:         s.Zones,
+		devices:       s.Devices,
+		lights:        s.Lights,
+		groupedLights: s.GroupedLights,
+		motions:       s.Motions,
+		buttons:       s.Buttons,
+		temperatures:  s.Temperatures,
+		powers:        s.DevicePowers,
+	})
+}
+
+func newSnapshot() *Snapshot {
+	return &Snapshot{
+		Devices:                     make(map[string]DeviceGet),
+		DevicePowers:                make(map[string]DevicePowerGet),
+		Rooms:                       make(map[string]RoomGet),
+		Zones:                       make(map[string]RoomGet),
+		Lights:                      make(map[string]LightGet),
+		GroupedLights:               make(map[string]GroupedLightGet),
+		Scenes:                      make(map[string]SceneGet),
+		SmartScenes:                 make(map[string]SmartSceneGet),
+		Motions:                     make(map[string]MotionGet),
+		Temperatures:                make(map[string]TemperatureGet),
+		LightLevels:                 make(map[string]LightLevelGet),
+		Contacts:                    make(map[string]ContactGet),
+		Tampers:                     make(map[string]TamperGet),
+		CameraMotions:               make(map[string]CameraMotionGet),
+		Buttons:                     make(map[string]ButtonGet),
+		RelativeRotaries:            make(map[string]RelativeRotaryGet),
+		EntertainmentConfigurations: make(map[string]EntertainmentConfigurationGet),
+	}
+}
+
+// add decodes a raw resource into the field of the snapshot matching its type.
+func (s *Snapshot) add(raw json.RawMessage) error {
+
+	var r ResourceGet
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return fmt.Errorf("unable to decode resource: %w", err)
+	}
+	if r.Id == nil || r.Type == nil {
+		return nil
+	}
+
+	id := *r.Id
+	switch *r.Type {
+	case ResourceGetTypeBridge:
+		var bridge BridgeGet
+		if err := json.Unmarshal(raw, &bridge); err != nil {
+			return fmt.Errorf("unable to decode bridge %s: %w", id, err)
+		}
+		s.Bridge = &bridge
+		return nil
+	case ResourceGetTypeDevice:
+		return decodeResource(raw, id, s.Devices)
+	case ResourceGetTypeDevicePower:
+		return decodeResource(raw, id, s.DevicePowers)
+	case ResourceGetTypeRoom:
+		return decodeResource(raw, id, s.Rooms)
+	case ResourceGetTypeZone:
+		return decodeResource(raw, id, s.Zones)
+	case ResourceGetTypeLight:
+		return decodeResource(raw, id, s.Lights)
+	case ResourceGetTypeGroupedLight:
+		return decodeResource(raw, id, s.GroupedLights)
+	case ResourceGetTypeScene:
+		return decodeResource(raw, id, s.Scenes)
+	case ResourceGetTypeSmartScene:
+		return decodeResource(raw, id, s.SmartScenes)
+	case ResourceGetTypeMotion:
+		return decodeResource(raw, id, s.Motions)
+	case ResourceGetTypeTemperature:
+		return decodeResource(raw, id, s.Temperatures)
+	case ResourceGetTypeLightLevel:
+		return decodeResource(raw, id, s.LightLevels)
+	case ResourceGetTypeContact:
+		return decodeResource(raw, id, s.Contacts)
+	case ResourceGetTypeTamper:
+		return decodeResource(raw, id, s.Tampers)
+	case ResourceGetTypeCameraMotion:
+		return decodeResource(raw, id, s.CameraMotions)
+	case ResourceGetTypeButton:
+		return decodeResource(raw, id, s.Buttons)
+	case ResourceGetTypeRelativeRotary:
+		return decodeResource(raw, id, s.RelativeRotaries)
+	case ResourceGetTypeEntertainmentConfiguration:
+		return decodeResource(raw, id, s.EntertainmentConfigurations)
+	default:
+		if s.Other == nil {
+			s.Other = make(map[ResourceGetType]map[string]json.RawMessage)
+		}
+		if s.Other[*r.Type] == nil {
+			s.Other[*r.Type] = make(map[string]json.RawMessage)
+		}
+		s.Other[*r.Type][id] = compactJSON(raw)
+		return nil
+	}
+}
+
+func decodeResource[T any](raw json.RawMessage, id string, resources map[string]T) error {
+	var r T
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return fmt.Errorf("unable to decode resource %s: %w", id, err)
+	}
+	resources[id] = r
+	return nil
+}
+
+// compactJSON removes the insignificant spaces of a raw JSON value, so that it does not depend on how it was indented.
+func compactJSON(raw json.RawMessage) json.RawMessage {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return raw
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,305 @@
+package openhue
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// SNAPSHOT DIFF
+//--------------------------------------------------------------------------------------------------------------------//
+
+// ChangeKind tells whether a resource has been added, removed or modified between two snapshots.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// SnapshotDiff lists the differences between two snapshots.
+type SnapshotDiff struct {
+	Changes []ResourceChange
+}
+
+// ResourceChange is a resource that differs between two snapshots.
+type ResourceChange struct {
+	Kind ChangeKind
+	// Type is the type of the resource, e.g. "light" or "scene".
+	Type string
+	Id   string
+	// Name is the name of the resource, from the newest snapshot when available.
+	Name string
+	// Fields lists the field-level changes of a modified resource.
+	Fields []FieldChange
+}
+
+// FieldChange is a field of a resource that differs between two snapshots.
+// Old is nil when the field has been added and New is nil when the field has been removed.
+type FieldChange struct {
+	// Path is the JSON path of the field, e.g. "dimming.brightness". Items of lists of resource identifiers, such as
+	// the actions of a scene or the children of a room, are referenced by ID, e.g. "actions[<light id>]".
+	Path string
+	Old  any
+	New  any
+}
+
+// DiffSnapshots compares two snapshots and returns the resources that have been added, removed or modified.
+func DiffSnapshots(before, after *Snapshot) (*SnapshotDiff, error) {
+
+	beforeResources, err := snapshotResources(before)
+	if err != nil {
+		return nil, err
+	}
+	afterResources, err := snapshotResources(after)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &SnapshotDiff{}
+
+	for _, key := range sortedKeys(resourceKey.less, beforeResources, afterResources) {
+		oldResource, newResource := beforeResources[key], afterResources[key]
+
+		change := ResourceChange{Type: key.rtype, Id: key.id, Name: resourceName(newResource)}
+		if change.Name == "" {
+			change.Name = resourceName(oldResource)
+		}
+
+		switch {
+		case oldResource == nil:
+			change.Kind = ChangeAdded
+		case newResource == nil:
+			change.Kind = ChangeRemoved
+		default:
+			change.Kind = ChangeModified
+			diffValues("", oldResource, newResource, &change.Fields)
+			if len(change.Fields) == 0 {
+				continue
+			}
+		}
+
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	return diff, nil
+}
+
+// IsEmpty returns true when both snapshots hold the same resources in the same state.
+func (d *SnapshotDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// String renders the diff in a human-readable form, one line per added or removed resource and per modified field.
+// Lines are prefixed with "+" for added resources, "-" for removed ones and "~" for modified ones, for instance
+// `~ light "Ceiling" (<id>): dimming.brightness 40 → 80` or `~ scene "Relax" (<id>): actions[<light id>] added`.
+func (d *SnapshotDiff) String() string {
+	var sb strings.Builder
+	for _, c := range d.Changes {
+		label := fmt.Sprintf("%s \"%s\" (%s)", c.Type, c.Name, c.Id)
+		switch c.Kind {
+		case ChangeAdded:
+			fmt.Fprintf(&sb, "+ %s added\n", label)
+		case ChangeRemoved:
+			fmt.Fprintf(&sb, "- %s removed\n", label)
+		case ChangeModified:
+			for _, f := range c.Fields {
+				fmt.Fprintf(&sb, "~ %s: %s\n", label, f)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func (f FieldChange) String() string {
+	switch {
+	case f.Old == nil:
+		return fmt.Sprintf("%s added", f.Path)
+	case f.New == nil:
+		return fmt.Sprintf("%s removed", f.Path)
+	default:
+		return fmt.Sprintf("%s %s → %s", f.Path, formatValue(f.Old), formatValue(f.New))
+	}
+}
+
+type resourceKey struct {
+	rtype string
+	id    string
+}
+
+// less orders the resources by type, then by ID.
+func (k resourceKey) less(other resourceKey) bool {
+	if k.rtype != other.rtype {
+		return k.rtype < other.rtype
+	}
+	return k.id < other.id
+}
+
+// snapshotResources flattens a snapshot into its resources, as generic JSON values indexed by type and ID.
+func snapshotResources(s *Snapshot) (map[resourceKey]map[string]any, error) {
+
+	resources := make(map[resourceKey]map[string]any)
+	if s == nil {
+		return resources, nil
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	add := func(category string, id string, value any) {
+		r, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		rtype, _ := r["type"].(string)
+		if rtype == "" {
+			rtype = category
+		}
+		resources[resourceKey{rtype: rtype, id: id}] = r
+	}
+
+	for category, value := range fields {
+		switch category {
+		case "taken_at":
+			continue
+		case "bridge":
+			if bridge, ok := value.(map[string]any); ok {
+				id, _ := bridge["id"].(string)
+				add(category, id, bridge)
+			}
+		case "other":
+			others, _ := value.(map[string]any)
+			for rtype, byId := range others {
+				items, _ := byId.(map[string]any)
+				for id, r := range items {
+					add(rtype, id, r)
+				}
+			}
+		default:
+			items, _ := value.(map[string]any)
+			for id, r := range items {
+				add(category, id, r)
+			}
+		}
+	}
+
+	return resources, nil
+}
+
+// diffValues recursively compares two generic JSON values and records the differences.
+func diffValues(path string, before, after any, changes *[]FieldChange) {
+
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+
+	switch b := before.(type) {
+	case map[string]any:
+		if a, ok := after.(map[string]any); ok {
+			for _, k := range sortedIds(b, a) {
+				bv, inBefore := b[k]
+				av, inAfter := a[k]
+				p := joinPath(path, k)
+				switch {
+				case !inBefore:
+					*changes = append(*changes, FieldChange{Path: p, New: av})
+				case !inAfter:
+					*changes = append(*changes, FieldChange{Path: p, Old: bv})
+				default:
+					diffValues(p, bv, av, changes)
+				}
+			}
+			return
+		}
+	case []any:
+		if a, ok := after.([]any); ok {
+			bi, bKeyed := indexById(b)
+			ai, aKeyed := indexById(a)
+			if bKeyed && aKeyed {
+				for _, k := range sortedIds(bi, ai) {
+					bv, inBefore := bi[k]
+					av, inAfter := ai[k]
+					p := fmt.Sprintf("%s[%s]", path, k)
+					switch {
+					case !inBefore:
+						*changes = append(*changes, FieldChange{Path: p, New: av})
+					case !inAfter:
+						*changes = append(*changes, FieldChange{Path: p, Old: bv})
+					default:
+						diffValues(p, bv, av, changes)
+					}
+				}
+				return
+			}
+		}
+	}
+
+	*changes = append(*changes, FieldChange{Path: path, Old: before, New: after})
+}
+
+// indexById indexes the items of a list by the resource they reference, either directly (rid) or as a target
+// (target.rid). It returns false if at least one item does not reference a resource.
+func indexById(items []any) (map[string]any, bool) {
+	indexed := make(map[string]any, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		id, ok := m["rid"].(string)
+		if !ok {
+			target, _ := m["target"].(map[string]any)
+			id, ok = target["rid"].(string)
+		}
+		if !ok {
+			return nil, false
+		}
+		indexed[id] = item
+	}
+	return indexed, true
+}
+
+func resourceName(r map[string]any) string {
+	if r == nil {
+		return ""
+	}
+	if metadata, ok := r["metadata"].(map[string]any); ok {
+		if name, ok := metadata["name"].(string); ok {
+			return name
+		}
+	}
+	if name, ok := r["name"].(string); ok {
+		return name
+	}
+	return ""
+}
+
+func formatValue(v any) string {
+	switch v.(type) {
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
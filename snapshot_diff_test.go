@@ -0,0 +1,78 @@
+package openhue
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func snapshotOf(t *testing.T, resources ...string) *Snapshot {
+	s := newSnapshot()
+	for _, r := range resources {
+		assert.NoError(t, s.add(json.RawMessage(r)))
+	}
+	return s
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	before := snapshotOf(t,
+		`{"id": "light-1", "type": "light", "metadata": {"name": "Ceiling"}, "dimming": {"brightness": 40}}`,
+		`{"id": "light-2", "type": "light", "metadata": {"name": "Desk"}}`,
+		`{"id": "scene-1", "type": "scene", "metadata": {"name": "Relax"}, "actions": [
+			{"target": {"rid": "light-1", "rtype": "light"}, "action": {"on": {"on": true}}}
+		]}`,
+	)
+	after := snapshotOf(t,
+		`{"id": "light-1", "type": "light", "metadata": {"name": "Ceiling"}, "dimming": {"brightness": 80}}`,
+		`{"id": "light-3", "type": "light", "metadata": {"name": "Lamp"}}`,
+		`{"id": "scene-1", "type": "scene", "metadata": {"name": "Relax"}, "actions": [
+			{"target": {"rid": "light-1", "rtype": "light"}, "action": {"on": {"on": true}}},
+			{"target": {"rid": "light-3", "rtype": "light"}, "action": {"on": {"on": false}}}
+		]}`,
+	)
+
+	diff, err := DiffSnapshots(before, after)
+	assert.NoError(t, err)
+	assert.False(t, diff.IsEmpty())
+
+	assert.Equal(t, []ResourceChange{
+		{Kind: ChangeModified, Type: "light", Id: "light-1", Name: "Ceiling", Fields: []FieldChange{
+			{Path: "dimming.brightness", Old: float64(40), New: float64(80)},
+		}},
+		{Kind: ChangeRemoved, Type: "light", Id: "light-2", Name: "Desk"},
+		{Kind: ChangeAdded, Type: "light", Id: "light-3", Name: "Lamp"},
+		{Kind: ChangeModified, Type: "scene", Id: "scene-1", Name: "Relax", Fields: []FieldChange{
+			{Path: "actions[light-3]", New: map[string]any{
+				"target": map[string]any{"rid": "light-3", "rtype": "light"},
+				"action": map[string]any{"on": map[string]any{"on": false}},
+			}},
+		}},
+	}, diff.Changes)
+
+	assert.Equal(t, `~ light "Ceiling" (light-1): dimming.brightness 40 → 80
+- light "Desk" (light-2) removed
++ light "Lamp" (light-3) added
+~ scene "Relax" (scene-1): actions[light-3] added
+`, diff.String())
+}
+
+func TestDiffSnapshots_Identical(t *testing.T) {
+	light := `{"id": "light-1", "type": "light", "dimming": {"brightness": 40}}`
+	other := `{"id": "geofence-1", "type": "geofence_client", "name": "phone"}`
+
+	diff, err := DiffSnapshots(snapshotOf(t, light, other), snapshotOf(t, light, other))
+	assert.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+	assert.Empty(t, diff.String())
+}
+
+func TestDiffSnapshots_Other(t *testing.T) {
+	before := snapshotOf(t, `{"id": "geofence-1", "type": "geofence_client", "name": "phone"}`)
+	after := snapshotOf(t, `{"id": "geofence-1", "type": "geofence_client", "name": "tablet"}`)
+
+	diff, err := DiffSnapshots(before, after)
+	assert.NoError(t, err)
+	assert.Equal(t, `~ geofence_client "tablet" (geofence-1): name phone → tablet
+`, diff.String())
+}
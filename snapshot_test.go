@@ -0,0 +1,60 @@
+package openhue
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const resourcesBody = `{"errors": [], "data": [
+	{"id": "bridge-1", "type": "bridge", "bridge_id": "001788fffe000000"},
+	{"id": "device-1", "type": "device", "metadata": {"name": "Ceiling"}, "services": [{"rid": "light-1", "rtype": "light"}]},
+	{"id": "light-1", "type": "light", "on": {"on": true}, "dimming": {"brightness": 40}},
+	{"id": "room-1", "type": "room", "metadata": {"name": "Kitchen"}, "children": [{"rid": "device-1", "rtype": "device"}]},
+	{"id": "scene-1", "type": "scene", "metadata": {"name": "Relax"}},
+	{"id": "geofence-1", "type": "geofence_client", "name": "phone"}
+]}`
+
+func TestSnapshot(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetResourcesWithResponse", mock.Anything, mock.Anything).Return(&GetResourcesResponse{
+		Body:         []byte(resourcesBody),
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	snapshot, err := home.Snapshot(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, "001788fffe000000", *snapshot.Bridge.BridgeId)
+	assert.Equal(t, float32(40), *snapshot.Lights["light-1"].Dimming.Brightness)
+	assert.Equal(t, "Kitchen", *snapshot.Rooms["room-1"].Metadata.Name)
+	assert.Equal(t, "Relax", *snapshot.Scenes["scene-1"].Metadata.Name)
+	assert.Contains(t, snapshot.Other[ResourceGetTypeGeofenceClient], "geofence-1")
+
+	assert.Equal(t, "room-1", *snapshot.Topology().RoomOf("light-1").Id)
+}
+
+func TestSnapshot_JSONRoundTrip(t *testing.T) {
+	home, m := NewTestHome()
+
+	m.On("GetResourcesWithResponse", mock.Anything, mock.Anything).Return(&GetResourcesResponse{
+		Body:         []byte(resourcesBody),
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}, nil)
+
+	snapshot, err := home.Snapshot(context.Background())
+	assert.NoError(t, err)
+
+	data, err := snapshot.JSON()
+	assert.NoError(t, err)
+
+	parsed, err := ParseSnapshot(data)
+	assert.NoError(t, err)
+	assert.True(t, snapshot.TakenAt.Equal(parsed.TakenAt))
+	parsed.TakenAt = snapshot.TakenAt
+	assert.Equal(t, snapshot, parsed)
+}
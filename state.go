@@ -0,0 +1,635 @@
+package openhue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// DESIRED STATE
+//--------------------------------------------------------------------------------------------------------------------//
+
+// DesiredState is the lighting setup of a home, as it should be on the bridge. Devices are referenced by ID, since
+// their names are part of the state, while rooms, zones, lights and scenes are referenced by name.
+//
+// Each section is authoritative once it is present: when Rooms or Zones is declared, even empty, the resources of that
+// type that are on the bridge but not in the state are deleted. A missing section leaves the resources of that type
+// untouched. Scenes are only managed in the rooms and zones the state declares, either in the Rooms and Zones sections
+// or as the group of one of its scenes: the scenes of the other groups are left untouched, so that a state can manage
+// the scenes of a single room.
+//
+// Example:
+//
+//	devices:
+//	  - id: 5f1d8e0c-...
+//	    name: Ceiling
+//	rooms:
+//	  - name: Kitchen
+//	    archetype: kitchen
+//	    devices: [Ceiling]
+//	scenes:
+//	  - name: Relax
+//	    group: {name: Kitchen, type: room}
+//	    actions:
+//	      - {light: Ceiling, on: true, brightness: 40}
+type DesiredState struct {
+	Devices []DesiredDevice `json:"devices,omitempty"`
+	Rooms   []DesiredRoom   `json:"rooms,omitempty"`
+	Zones   []DesiredZone   `json:"zones,omitempty"`
+	Scenes  []PortableScene `json:"scenes,omitempty"`
+}
+
+// DesiredDevice is the name and archetype a device should have.
+type DesiredDevice struct {
+	Id        string            `json:"id"`
+	Name      string            `json:"name"`
+	Archetype *ProductArchetype `json:"archetype,omitempty"`
+}
+
+// DesiredRoom is a room and the devices, referenced by name, it should contain.
+type DesiredRoom struct {
+	Name      string        `json:"name"`
+	Archetype RoomArchetype `json:"archetype,omitempty"`
+	Devices   []string      `json:"devices"`
+}
+
+// DesiredZone is a zone and the lights, referenced by name, it should contain.
+type DesiredZone struct {
+	Name      string        `json:"name"`
+	Archetype RoomArchetype `json:"archetype,omitempty"`
+	Lights    []string      `json:"lights"`
+}
+
+// ParseDesiredState decodes a DesiredState from either its YAML or its JSON representation.
+func ParseDesiredState(data []byte) (*DesiredState, error) {
+	state := &DesiredState{}
+	if err := unmarshalYAML(data, state); err != nil {
+		return nil, fmt.Errorf("unable to parse desired state: %w", err)
+	}
+	return state, nil
+}
+
+// StateChangeKind tells whether a StateChange creates, updates or deletes a resource.
+type StateChangeKind string
+
+const (
+	StateCreate StateChangeKind = "create"
+	StateUpdate StateChangeKind = "update"
+	StateDelete StateChangeKind = "delete"
+)
+
+// StatePlan lists the changes that bring the bridge to a DesiredState, see PlanState.
+type StatePlan struct {
+	Changes []StateChange
+
+	// groups indexes the IDs of the existing rooms and zones by name
+	groups map[PortableGroupRef]string
+}
+
+// StateChange is a change to make to a resource of the bridge.
+type StateChange struct {
+	Kind StateChangeKind
+	Type ResourceIdentifierRtype
+	// Id is the ID of the resource, empty when the resource is to be created.
+	Id   string
+	Name string
+	// Fields describes the updated fields, e.g. `archetype: living_room → kitchen` or `devices: +Desk -Plug`.
+	Fields []string
+
+	apply func(ctx context.Context, h *Home, groups map[PortableGroupRef]string) error
+}
+
+// HasDrift returns true if the bridge differs from the desired state.
+func (p *StatePlan) HasDrift() bool {
+	return len(p.Changes) > 0
+}
+
+// String renders the plan in a human-readable form, which is the output of a dry-run.
+func (p *StatePlan) String() string {
+	var sb strings.Builder
+	for _, c := range p.Changes {
+		prefix := map[StateChangeKind]string{StateCreate: "+", StateUpdate: "~", StateDelete: "-"}[c.Kind]
+		fmt.Fprintf(&sb, "%s %s %s \"%s\"\n", prefix, c.Kind, c.Type, c.Name)
+		for _, f := range c.Fields {
+			fmt.Fprintf(&sb, "    %s\n", f)
+		}
+	}
+	return sb.String()
+}
+
+// PlanState compares the bridge with the desired state and returns the changes that would bring the bridge to that
+// state, without making any of them. Names that cannot be resolved, or that are ambiguous, fail the planning.
+//
+// Example:
+//
+//	plan, err := home.PlanState(ctx, state)
+//	if plan.HasDrift() {
+//		fmt.Print(plan)
+//		err = home.ApplyState(ctx, plan)
+//	}
+func (h *Home) PlanState(ctx context.Context, state *DesiredState) (*StatePlan, error) {
+
+	if state == nil {
+		return nil, errors.New("illegal arguments, desired state must be set")
+	}
+
+	devices, err := h.GetDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := h.GetRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := h.GetZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lights, err := h.GetLights(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var scenes map[string]SceneGet
+	if state.Scenes != nil {
+		if scenes, err = h.GetScenes(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	pl := &statePlanner{
+		plan:    &StatePlan{groups: make(map[PortableGroupRef]string)},
+		state:   state,
+		devices: devices,
+		rooms:   rooms,
+		zones:   zones,
+		scenes:  scenes,
+		idx:     newNameIndexFrom(rooms, zones, lights),
+	}
+
+	for id, room := range rooms {
+		if n := groupName(room); n != nil {
+			pl.plan.groups[groupKey(*n, ResourceIdentifierRtypeRoom)] = id
+		}
+	}
+	for id, zone := range zones {
+		if n := groupName(zone); n != nil {
+			pl.plan.groups[groupKey(*n, ResourceIdentifierRtypeZone)] = id
+		}
+	}
+
+	// deletions first, so that the devices of deleted rooms are free to join other rooms
+	pl.planDeletes()
+	pl.planDevices()
+	pl.planRooms()
+	pl.planZones()
+	pl.planScenes()
+
+	if len(pl.errs) > 0 {
+		return nil, errors.Join(pl.errs...)
+	}
+
+	return pl.plan, nil
+}
+
+// ApplyState makes the changes of the plan, in order. It stops at the first change that fails.
+func (h *Home) ApplyState(ctx context.Context, p *StatePlan) error {
+
+	groups := make(map[PortableGroupRef]string, len(p.groups))
+	for k, id := range p.groups {
+		groups[k] = id
+	}
+
+	for _, c := range p.Changes {
+		if err := c.apply(ctx, h, groups); err != nil {
+			return fmt.Errorf("unable to %s %s \"%s\": %w", c.Kind, c.Type, c.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// statePlanner holds the state of the bridge while a StatePlan is computed.
+type statePlanner struct {
+	plan  *StatePlan
+	state *DesiredState
+	errs  []error
+
+	devices map[string]DeviceGet
+	rooms   map[string]RoomGet
+	zones   map[string]RoomGet
+	scenes  map[string]SceneGet
+	idx     *nameIndex
+}
+
+func (pl *statePlanner) add(c StateChange) {
+	pl.plan.Changes = append(pl.plan.Changes, c)
+}
+
+func (pl *statePlanner) fail(format string, args ...any) {
+	pl.errs = append(pl.errs, fmt.Errorf(format, args...))
+}
+
+func (pl *statePlanner) planDeletes() {
+
+	if pl.state.Scenes != nil {
+		declared := make(map[string]bool)
+		managed := make(map[PortableGroupRef]bool)
+		for _, ps := range pl.state.Scenes {
+			declared[sceneKey(ps.Group, ps.Name)] = true
+			managed[groupKey(ps.Group.Name, ps.Group.Type)] = true
+		}
+		for _, r := range pl.state.Rooms {
+			managed[groupKey(r.Name, ResourceIdentifierRtypeRoom)] = true
+		}
+		for _, z := range pl.state.Zones {
+			managed[groupKey(z.Name, ResourceIdentifierRtypeZone)] = true
+		}
+		for _, id := range sortedIds(pl.scenes) {
+			scene := pl.scenes[id]
+			group, ok := pl.groupRefOf(scene.Group)
+			if !ok || !managed[group] || declared[sceneKey(group, sceneName(scene))] {
+				continue
+			}
+			pl.add(StateChange{Kind: StateDelete, Type: ResourceIdentifierRtypeScene, Id: id, Name: sceneName(scene),
+				apply: func(ctx context.Context, h *Home, _ map[PortableGroupRef]string) error {
+					return h.DeleteScene(ctx, id)
+				}})
+		}
+	}
+
+	if pl.state.Zones != nil {
+		declared := make(map[string]bool)
+		for _, z := range pl.state.Zones {
+			declared[normalizeName(z.Name)] = true
+		}
+		pl.deleteUndeclared(pl.zones, ResourceIdentifierRtypeZone, declared, (*Home).DeleteZone)
+	}
+
+	if pl.state.Rooms != nil {
+		declared := make(map[string]bool)
+		for _, r := range pl.state.Rooms {
+			declared[normalizeName(r.Name)] = true
+		}
+		pl.deleteUndeclared(pl.rooms, ResourceIdentifierRtypeRoom, declared, (*Home).DeleteRoom)
+	}
+}
+
+func (pl *statePlanner) deleteUndeclared(groups map[string]RoomGet, rtype ResourceIdentifierRtype, declared map[string]bool,
+	del func(*Home, context.Context, string) error) {
+
+	for _, id := range sortedIds(groups) {
+		var name string
+		if n := groupName(groups[id]); n != nil {
+			name = *n
+		}
+		if declared[normalizeName(name)] {
+			continue
+		}
+		delete(pl.plan.groups, groupKey(name, rtype))
+		pl.add(StateChange{Kind: StateDelete, Type: rtype, Id: id, Name: name,
+			apply: func(ctx context.Context, h *Home, _ map[PortableGroupRef]string) error {
+				return del(h, ctx, id)
+			}})
+	}
+}
+
+func (pl *statePlanner) planDevices() {
+	for _, d := range pl.state.Devices {
+		device, ok := pl.devices[d.Id]
+		if !ok {
+			pl.fail("device %s: %w", d.Id, ErrNotFound)
+			continue
+		}
+
+		var fields []string
+		if current := deviceName(device); current != d.Name {
+			fields = append(fields, fmt.Sprintf("name: %s → %s", current, d.Name))
+		}
+		if d.Archetype != nil {
+			var current *ProductArchetype
+			if device.Metadata != nil {
+				current = device.Metadata.Archetype
+			}
+			if !equalPtr(current, d.Archetype) {
+				fields = append(fields, fmt.Sprintf("archetype: %s → %s", formatPtr(current), *d.Archetype))
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		id, name, archetype := d.Id, d.Name, d.Archetype
+		pl.add(StateChange{Kind: StateUpdate, Type: ResourceIdentifierRtypeDevice, Id: id, Name: name, Fields: fields,
+			apply: func(ctx context.Context, h *Home, _ map[PortableGroupRef]string) error {
+				return h.UpdateDevice(ctx, id, DevicePut{Metadata: &struct {
+					Archetype *ProductArchetype `json:"archetype,omitempty"`
+					Name      *string           `json:"name,omitempty"`
+				}{Archetype: archetype, Name: &name}})
+			}})
+	}
+}
+
+func (pl *statePlanner) planRooms() {
+
+	// devices are referenced by the name they will have once the device changes are applied
+	names := make(map[string]string)
+	for id, device := range pl.devices {
+		names[id] = deviceName(device)
+	}
+	for _, d := range pl.state.Devices {
+		names[d.Id] = d.Name
+	}
+
+	for _, r := range pl.state.Rooms {
+		var children []string
+		for _, name := range r.Devices {
+			var candidates []string
+			for id, n := range names {
+				if strings.EqualFold(n, name) {
+					candidates = append(candidates, id)
+				}
+			}
+			id, reason := pickCandidate(candidates)
+			if reason != "" {
+				pl.fail("room \"%s\": device \"%s\" %s", r.Name, name, reason)
+				continue
+			}
+			children = append(children, id)
+		}
+		pl.planGroup(ResourceIdentifierRtypeRoom, pl.rooms, r.Name, r.Archetype, ResourceIdentifierRtypeDevice, children, names)
+	}
+}
+
+func (pl *statePlanner) planZones() {
+	for _, z := range pl.state.Zones {
+		var children []string
+		for _, name := range z.Lights {
+			id, reason := pl.idx.resolveLight("", name)
+			if reason != "" {
+				pl.fail("zone \"%s\": light \"%s\" %s", z.Name, name, reason)
+				continue
+			}
+			children = append(children, id)
+		}
+		pl.planGroup(ResourceIdentifierRtypeZone, pl.zones, z.Name, z.Archetype, ResourceIdentifierRtypeLight, children, pl.idx.lightNames)
+	}
+}
+
+// planGroup plans the creation or the update of a room or a zone, whose children are of the given type.
+func (pl *statePlanner) planGroup(rtype ResourceIdentifierRtype, existing map[string]RoomGet, name string,
+	archetype RoomArchetype, childType ResourceIdentifierRtype, children []string, childNames map[string]string) {
+
+	sort.Strings(children)
+	key := groupKey(name, rtype)
+	id, exists := pl.plan.groups[key]
+
+	var fields []string
+	if exists {
+		group := existing[id]
+		if archetype != "" && (group.Metadata == nil || !equalPtr(group.Metadata.Archetype, &archetype)) {
+			var current *RoomArchetype
+			if group.Metadata != nil {
+				current = group.Metadata.Archetype
+			}
+			fields = append(fields, fmt.Sprintf("archetype: %s → %s", formatPtr(current), archetype))
+		}
+
+		current := groupChildren(group, childType)
+		sort.Strings(current)
+		if f := childrenDiff(current, children, childNames); f != "" {
+			fields = append(fields, fmt.Sprintf("%ss: %s", childType, f))
+		}
+
+		if len(fields) == 0 {
+			return
+		}
+	}
+
+	body := RoomPut{Children: &[]ResourceIdentifier{}, Metadata: &struct {
+		Archetype *RoomArchetype `json:"archetype,omitempty"`
+		Name      *string        `json:"name,omitempty"`
+	}{Name: &name}}
+	if archetype != "" {
+		body.Metadata.Archetype = &archetype
+	}
+	for _, child := range children {
+		*body.Children = append(*body.Children, ResourceIdentifier{Rid: &child, Rtype: &childType})
+	}
+
+	if exists {
+		pl.add(StateChange{Kind: StateUpdate, Type: rtype, Id: id, Name: name, Fields: fields,
+			apply: func(ctx context.Context, h *Home, _ map[PortableGroupRef]string) error {
+				if rtype == ResourceIdentifierRtypeZone {
+					return h.UpdateZone(ctx, id, body)
+				}
+				return h.UpdateRoom(ctx, id, body)
+			}})
+		return
+	}
+
+	pl.add(StateChange{Kind: StateCreate, Type: rtype, Name: name,
+		apply: func(ctx context.Context, h *Home, groups map[PortableGroupRef]string) error {
+			var created *ResourceIdentifier
+			var err error
+			if rtype == ResourceIdentifierRtypeZone {
+				created, err = h.CreateZone(ctx, body)
+			} else {
+				created, err = h.CreateRoom(ctx, body)
+			}
+			if err != nil {
+				return err
+			}
+			groups[key] = *created.Rid
+			return nil
+		}})
+}
+
+func (pl *statePlanner) planScenes() {
+
+	declared := make(map[PortableGroupRef]bool)
+	for _, r := range pl.state.Rooms {
+		declared[groupKey(r.Name, ResourceIdentifierRtypeRoom)] = true
+	}
+	for _, z := range pl.state.Zones {
+		declared[groupKey(z.Name, ResourceIdentifierRtypeZone)] = true
+	}
+
+	for _, ps := range pl.state.Scenes {
+		key := groupKey(ps.Group.Name, ps.Group.Type)
+		groupId, exists := pl.plan.groups[key]
+		if !exists && !declared[key] {
+			pl.fail("scene \"%s\": %s \"%s\": %w", ps.Name, ps.Group.Type, ps.Group.Name, ErrNotFound)
+			continue
+		}
+
+		actions := make(map[string]PortableAction)
+		for _, pa := range ps.Actions {
+			lightId, reason := pl.idx.resolveLight(groupId, pa.Light)
+			if reason != "" {
+				pl.fail("scene \"%s\": light \"%s\" %s", ps.Name, pa.Light, reason)
+				continue
+			}
+			pa.Light = pl.idx.lightNames[lightId]
+			actions[lightId] = pa
+		}
+
+		var sceneId string
+		if exists {
+			for id, scene := range pl.scenes {
+				if scene.Group != nil && scene.Group.Rid != nil && *scene.Group.Rid == groupId &&
+					normalizeName(sceneName(scene)) == normalizeName(ps.Name) {
+					sceneId = id
+				}
+			}
+		}
+
+		if sceneId == "" {
+			pl.add(StateChange{Kind: StateCreate, Type: ResourceIdentifierRtypeScene, Name: ps.Name,
+				apply: func(ctx context.Context, h *Home, groups map[PortableGroupRef]string) error {
+					groupId, groupType := groups[key], ps.Group.Type
+					sceneType := ScenePostTypeScene
+					_, err := h.CreateScene(ctx, ScenePost{
+						Actions:     actionPosts(actions),
+						Group:       ResourceIdentifier{Rid: &groupId, Rtype: &groupType},
+						Metadata:    SceneMetadata{Name: &ps.Name},
+						Palette:     ps.Palette,
+						Speed:       ps.Speed,
+						AutoDynamic: ps.AutoDynamic,
+						Type:        &sceneType,
+					})
+					return err
+				}})
+			continue
+		}
+
+		fields := pl.sceneDiff(pl.scenes[sceneId], ps, actions)
+		if len(fields) == 0 {
+			continue
+		}
+
+		pl.add(StateChange{Kind: StateUpdate, Type: ResourceIdentifierRtypeScene, Id: sceneId, Name: ps.Name, Fields: fields,
+			apply: func(ctx context.Context, h *Home, _ map[PortableGroupRef]string) error {
+				posts := actionPosts(actions)
+				return h.UpdateScene(ctx, sceneId, ScenePut{
+					Actions:     &posts,
+					Palette:     ps.Palette,
+					Speed:       ps.Speed,
+					AutoDynamic: ps.AutoDynamic,
+				})
+			}})
+	}
+}
+
+// sceneDiff describes how the desired scene differs from the scene on the bridge. The palette, the speed and the
+// auto dynamic flag are only compared when the desired scene sets them.
+func (pl *statePlanner) sceneDiff(scene SceneGet, ps PortableScene, actions map[string]PortableAction) []string {
+
+	var fields []string
+
+	current := make(map[string]PortableAction)
+	if scene.Actions != nil {
+		for _, a := range *scene.Actions {
+			if a.Target != nil && a.Target.Rid != nil {
+				current[*a.Target.Rid] = newPortableAction(pl.idx.lightNames[*a.Target.Rid], a)
+			}
+		}
+	}
+
+	for _, lightId := range sortedIds(current, actions) {
+		before, inBefore := current[lightId]
+		after, inAfter := actions[lightId]
+		name := pl.idx.lightNames[lightId]
+		switch {
+		case !inBefore:
+			fields = append(fields, fmt.Sprintf("light %s: added", name))
+		case !inAfter:
+			fields = append(fields, fmt.Sprintf("light %s: removed", name))
+		case !sameJSON(before, after):
+			fields = append(fields, fmt.Sprintf("light %s: changed", name))
+		}
+	}
+
+	if ps.Palette != nil && !sameJSON(scene.Palette, ps.Palette) {
+		fields = append(fields, "palette: changed")
+	}
+	if ps.Speed != nil && !equalPtr(scene.Speed, ps.Speed) {
+		fields = append(fields, fmt.Sprintf("speed: %s → %v", formatPtr(scene.Speed), *ps.Speed))
+	}
+	if ps.AutoDynamic != nil && !equalPtr(scene.AutoDynamic, ps.AutoDynamic) {
+		fields = append(fields, fmt.Sprintf("auto_dynamic: %s → %v", formatPtr(scene.AutoDynamic), *ps.AutoDynamic))
+	}
+
+	return fields
+}
+
+// groupRefOf returns the name and type of the room or zone a scene belongs to.
+func (pl *statePlanner) groupRefOf(ref *ResourceIdentifier) (PortableGroupRef, bool) {
+	if ref == nil || ref.Rid == nil {
+		return PortableGroupRef{}, false
+	}
+	name, ok := pl.idx.groupNames[*ref.Rid]
+	if !ok {
+		return PortableGroupRef{}, false
+	}
+	return groupKey(name, pl.idx.groupTypes[*ref.Rid]), true
+}
+
+// groupKey identifies a room or a zone by name, case-insensitively.
+func groupKey(name string, rtype ResourceIdentifierRtype) PortableGroupRef {
+	return PortableGroupRef{Name: normalizeName(name), Type: rtype}
+}
+
+func sceneKey(group PortableGroupRef, name string) string {
+	key := groupKey(group.Name, group.Type)
+	return fmt.Sprintf("%s/%s/%s", key.Type, key.Name, normalizeName(name))
+}
+
+func sceneName(scene SceneGet) string {
+	if scene.Metadata == nil || scene.Metadata.Name == nil {
+		return ""
+	}
+	return *scene.Metadata.Name
+}
+
+// childrenDiff describes the added and removed children, both lists being sorted, e.g. `+Desk -Plug`.
+func childrenDiff(before, after []string, names map[string]string) string {
+	var changes []string
+	for _, id := range after {
+		if !slices.Contains(before, id) {
+			changes = append(changes, "+"+names[id])
+		}
+	}
+	for _, id := range before {
+		if !slices.Contains(after, id) {
+			changes = append(changes, "-"+names[id])
+		}
+	}
+	return strings.Join(changes, " ")
+}
+
+func actionPosts(actions map[string]PortableAction) []ActionPost {
+	posts := make([]ActionPost, 0, len(actions))
+	for _, lightId := range sortedIds(actions) {
+		pa := actions[lightId]
+		posts = append(posts, pa.actionPost(lightId))
+	}
+	return posts
+}
+
+func sameJSON(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
+
+func formatPtr[T any](v *T) string {
+	if v == nil {
+		return "<none>"
+	}
+	return fmt.Sprint(*v)
+}
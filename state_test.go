@@ -0,0 +1,174 @@
+package openhue
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const desiredStateYAML = `
+devices:
+  - id: dev-1
+    name: Ceiling lamp
+rooms:
+  - name: Kitchen
+    archetype: kitchen
+    devices: [Ceiling lamp, desk]
+  - name: Office
+    devices: []
+scenes:
+  - name: Relax
+    group: {name: Kitchen, type: room}
+    actions:
+      - {light: Ceiling, on: true, brightness: 80}
+  - name: Focus
+    group: {name: Office, type: room}
+    actions:
+      - {light: Desk, on: true}
+`
+
+func mockLiveBridge(t *testing.T, m *ClientWithResponsesMock) {
+	m.On("GetDevicesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetDevicesResponse](t, `[
+		{"id": "dev-1", "metadata": {"name": "Hue lamp 1"}, "services": [{"rid": "light-1", "rtype": "light"}]},
+		{"id": "dev-2", "metadata": {"name": "Desk"}, "services": [{"rid": "light-2", "rtype": "light"}]}
+	]`), nil)
+	m.On("GetRoomsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetRoomsResponse](t, `[
+		{"id": "room-1", "metadata": {"name": "Kitchen", "archetype": "living_room"}, "children": [{"rid": "dev-1", "rtype": "device"}]},
+		{"id": "room-2", "metadata": {"name": "Attic"}}
+	]`), nil)
+	m.On("GetZonesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetZonesResponse](t, `[]`), nil)
+	m.On("GetLightsWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetLightsResponse](t, `[
+		{"id": "light-1", "metadata": {"name": "Ceiling"}},
+		{"id": "light-2", "metadata": {"name": "Desk"}}
+	]`), nil)
+	m.On("GetScenesWithResponse", mock.Anything, mock.Anything).Return(dataResponse[*GetScenesResponse](t, `[
+		{"id": "scene-1", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Relax"},
+			"actions": [{"target": {"rid": "light-1", "rtype": "light"}, "action": {"on": {"on": true}, "dimming": {"brightness": 40}}}]},
+		{"id": "scene-2", "group": {"rid": "room-1", "rtype": "room"}, "metadata": {"name": "Old"}}
+	]`), nil)
+}
+
+func TestPlanState(t *testing.T) {
+	home, m := NewTestHome()
+	mockLiveBridge(t, m)
+
+	state, err := ParseDesiredState([]byte(desiredStateYAML))
+	assert.NoError(t, err)
+
+	plan, err := home.PlanState(context.Background(), state)
+	assert.NoError(t, err)
+	assert.True(t, plan.HasDrift())
+
+	assert.Equal(t, `- delete scene "Old"
+- delete room "Attic"
+~ update device "Ceiling lamp"
+    name: Hue lamp 1 → Ceiling lamp
+~ update room "Kitchen"
+    archetype: living_room → kitchen
+    devices: +Desk
++ create room "Office"
+~ update scene "Relax"
+    light Ceiling: changed
++ create scene "Focus"
+`, plan.String())
+
+	m.AssertNotCalled(t, "DeleteSceneWithResponse", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlanState_NoDrift(t *testing.T) {
+	home, m := NewTestHome()
+	mockLiveBridge(t, m)
+
+	state, err := ParseDesiredState([]byte(`
+rooms:
+  - name: kitchen
+    archetype: living_room
+    devices: [Hue lamp 1]
+  - name: Attic
+    devices: []
+`))
+	assert.NoError(t, err)
+
+	plan, err := home.PlanState(context.Background(), state)
+	assert.NoError(t, err)
+	assert.False(t, plan.HasDrift())
+	assert.Empty(t, plan.String())
+}
+
+func TestPlanState_PartialScenes(t *testing.T) {
+	home, m := NewTestHome()
+	mockLiveBridge(t, m)
+
+	// the scenes of the kitchen are not managed by this state
+	state, err := ParseDesiredState([]byte(`
+scenes:
+  - name: Night
+    group: {name: Attic, type: room}
+    actions:
+      - {light: Desk, on: false}
+`))
+	assert.NoError(t, err)
+
+	plan, err := home.PlanState(context.Background(), state)
+	assert.NoError(t, err)
+	assert.Equal(t, `+ create scene "Night"
+`, plan.String())
+}
+
+func TestPlanState_UnresolvedNames(t *testing.T) {
+	home, m := NewTestHome()
+	mockLiveBridge(t, m)
+
+	state, err := ParseDesiredState([]byte(`
+devices:
+  - {id: dev-9, name: Ghost}
+scenes:
+  - name: Relax
+    group: {name: Garage, type: room}
+    actions: []
+`))
+	assert.NoError(t, err)
+
+	_, err = home.PlanState(context.Background(), state)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, "device dev-9")
+	assert.ErrorContains(t, err, `scene "Relax": room "Garage"`)
+}
+
+func TestApplyState(t *testing.T) {
+	home, m := NewTestHome()
+	mockLiveBridge(t, m)
+
+	ok := &http.Response{StatusCode: http.StatusOK}
+	m.On("DeleteSceneWithResponse", mock.Anything, "scene-2", mock.Anything).Return(&DeleteSceneResponse{HTTPResponse: ok}, nil)
+	m.On("DeleteRoomWithResponse", mock.Anything, "room-2", mock.Anything).Return(&DeleteRoomResponse{HTTPResponse: ok}, nil)
+	m.On("UpdateDeviceWithResponse", mock.Anything, "dev-1", mock.Anything, mock.Anything).Return(&UpdateDeviceResponse{HTTPResponse: ok}, nil)
+	m.On("UpdateRoomWithResponse", mock.Anything, "room-1", mock.Anything, mock.Anything).Return(&UpdateRoomResponse{HTTPResponse: ok}, nil)
+	m.On("CreateRoomWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateRoomResponse](t, `[{"rid": "room-3", "rtype": "room"}]`), nil)
+	m.On("UpdateSceneWithResponse", mock.Anything, "scene-1", mock.Anything, mock.Anything).Return(&UpdateSceneResponse{HTTPResponse: ok}, nil)
+	m.On("CreateSceneWithResponse", mock.Anything, mock.Anything, mock.Anything).Return(dataResponse[*CreateSceneResponse](t, `[{"rid": "scene-3", "rtype": "scene"}]`), nil)
+
+	state, err := ParseDesiredState([]byte(desiredStateYAML))
+	assert.NoError(t, err)
+
+	plan, err := home.PlanState(context.Background(), state)
+	assert.NoError(t, err)
+
+	err = home.ApplyState(context.Background(), plan)
+	assert.NoError(t, err)
+
+	m.AssertCalled(t, "UpdateRoomWithResponse", mock.Anything, "room-1", mock.MatchedBy(func(body RoomPut) bool {
+		return *body.Metadata.Archetype == RoomArchetypeKitchen && len(*body.Children) == 2
+	}), mock.Anything)
+
+	m.AssertCalled(t, "UpdateSceneWithResponse", mock.Anything, "scene-1", mock.MatchedBy(func(body ScenePut) bool {
+		return len(*body.Actions) == 1 && *(*body.Actions)[0].Action.Dimming.Brightness == 80
+	}), mock.Anything)
+
+	m.AssertCalled(t, "CreateSceneWithResponse", mock.Anything, mock.MatchedBy(func(body ScenePost) bool {
+		return *body.Metadata.Name == "Focus" && *body.Group.Rid == "room-3" && *body.Actions[0].Target.Rid == "light-2"
+	}), mock.Anything)
+}
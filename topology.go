@@ -0,0 +1,288 @@
+package openhue
+
+import (
+	"context"
+)
+
+//--------------------------------------------------------------------------------------------------------------------//
+// TOPOLOGY
+//--------------------------------------------------------------------------------------------------------------------//
+
+// Topology is a resolved view of the home, linking the rooms and zones to their devices, and the devices to their
+// services. Every link can be navigated in both directions, for instance from a room to its motion sensors, or from
+// a motion sensor to its room.
+type Topology struct {
+	Bridge  *BridgeGet
+	Rooms   map[string]*RoomNode
+	Zones   map[string]*ZoneNode
+	Devices map[string]*DeviceNode
+	Lights  map[string]*LightNode
+
+	// owners indexes the devices by the ID of their services
+	owners map[string]*DeviceNode
+}
+
+// RoomNode is a room linked to its devices.
+type RoomNode struct {
+	RoomGet
+	Devices      []*DeviceNode
+	GroupedLight *GroupedLightGet
+}
+
+// ZoneNode is a zone linked to its lights.
+type ZoneNode struct {
+	RoomGet
+	Lights       []*LightNode
+	GroupedLight *GroupedLightGet
+}
+
+// DeviceNode is a device linked to its room and to its services.
+type DeviceNode struct {
+	DeviceGet
+	// Room is the room the device belongs to, nil if the device is not assigned to any room.
+	Room         *RoomNode
+	Lights       []*LightNode
+	Motions      []*MotionGet
+	Buttons      []*ButtonGet
+	Temperatures []*TemperatureGet
+	Power        *DevicePowerGet
+}
+
+// LightNode is a light linked to its device and to the zones it belongs to.
+type LightNode struct {
+	LightGet
+	Device *DeviceNode
+	Zones  []*ZoneNode
+}
+
+// Room returns the room of the light, or nil if its device is not assigned to any room.
+func (l *LightNode) Room() *RoomNode {
+	if l.Device == nil {
+		return nil
+	}
+	return l.Device.Room
+}
+
+// DeviceOf returns the device owning the service of the given ID, or nil if the service is unknown.
+func (t *Topology) DeviceOf(serviceId string) *DeviceNode {
+	return t.owners[serviceId]
+}
+
+// RoomOf returns the room of the device or the service of the given ID, or nil if it is not assigned to any room.
+//
+// Example:
+//
+//	room := topology.RoomOf(motionSensorId)
+func (t *Topology) RoomOf(id string) *RoomNode {
+	device, ok := t.Devices[id]
+	if !ok {
+		device = t.owners[id]
+	}
+	if device == nil {
+		return nil
+	}
+	return device.Room
+}
+
+// ZonesOf returns the zones containing the light or the lights of the device of the given ID.
+func (t *Topology) ZonesOf(id string) []*ZoneNode {
+	if light, ok := t.Lights[id]; ok {
+		return light.Zones
+	}
+
+	device, ok := t.Devices[id]
+	if !ok {
+		return nil
+	}
+
+	var zones []*ZoneNode
+	seen := make(map[*ZoneNode]bool)
+	for _, light := range device.Lights {
+		for _, zone := range light.Zones {
+			if !seen[zone] {
+				seen[zone] = true
+				zones = append(zones, zone)
+			}
+		}
+	}
+	return zones
+}
+
+// GetTopology fetches the rooms, zones, devices and their services and links them together.
+func (h *Home) GetTopology(ctx context.Context) (*Topology, error) {
+
+	var data topologyData
+	var err error
+
+	if data.bridge, err = h.GetBridge(ctx); err != nil {
+		return nil, err
+	}
+	if data.rooms, err = h.GetRooms(ctx); err != nil {
+		return nil, err
+	}
+	if data.zones, err = h.GetZones(ctx); err != nil {
+		return nil, err
+	}
+	if data.devices, err = h.GetDevices(ctx); err != nil {
+		return nil, err
+	}
+	if data.lights, err = h.GetLights(ctx); err != nil {
+		return nil, err
+	}
+	if data.groupedLights, err = h.GetGroupedLights(ctx); err != nil {
+		return nil, err
+	}
+	if data.motions, err = h.GetMotionSensors(ctx); err != nil {
+		return nil, err
+	}
+	if data.buttons, err = h.GetButtons(ctx); err != nil {
+		return nil, err
+	}
+	if data.temperatures, err = h.GetTemperatureSensors(ctx); err != nil {
+		return nil, err
+	}
+	if data.powers, err = h.GetDevicePowers(ctx); err != nil {
+		return nil, err
+	}
+
+	return newTopology(data), nil
+}
+
+// topologyData holds the raw resources a Topology is built from.
+type topologyData struct {
+	bridge        *BridgeGet
+	rooms         map[string]RoomGet
+	zones         map[string]RoomGet
+	devices       map[string]DeviceGet
+	lights        map[string]LightGet
+	groupedLights map[string]GroupedLightGet
+	motions       map[string]MotionGet
+	buttons       map[string]ButtonGet
+	temperatures  map[string]TemperatureGet
+	powers        map[string]DevicePowerGet
+}
+
+func newTopology(data topologyData) *Topology {
+
+	t := &Topology{
+		Bridge:  data.bridge,
+		Rooms:   make(map[string]*RoomNode),
+		Zones:   make(map[string]*ZoneNode),
+		Devices: make(map[string]*DeviceNode),
+		Lights:  make(map[string]*LightNode),
+		owners:  make(map[string]*DeviceNode),
+	}
+
+	// devices and their services
+	for id, device := range data.devices {
+		node := &DeviceNode{DeviceGet: device}
+		t.Devices[id] = node
+
+		if device.Services == nil {
+			continue
+		}
+		for _, s := range *device.Services {
+			if s.Rid == nil || s.Rtype == nil {
+				continue
+			}
+			t.owners[*s.Rid] = node
+
+			switch *s.Rtype {
+			case ResourceIdentifierRtypeLight:
+				if light, ok := data.lights[*s.Rid]; ok {
+					lightNode := &LightNode{LightGet: light, Device: node}
+					t.Lights[*s.Rid] = lightNode
+					node.Lights = append(node.Lights, lightNode)
+				}
+			case ResourceIdentifierRtypeMotion:
+				if motion, ok := data.motions[*s.Rid]; ok {
+					node.Motions = append(node.Motions, &motion)
+				}
+			case ResourceIdentifierRtypeButton:
+				if button, ok := data.buttons[*s.Rid]; ok {
+					node.Buttons = append(node.Buttons, &button)
+				}
+			case ResourceIdentifierRtypeTemperature:
+				if temperature, ok := data.temperatures[*s.Rid]; ok {
+					node.Temperatures = append(node.Temperatures, &temperature)
+				}
+			case ResourceIdentifierRtypeDevicePower:
+				if power, ok := data.powers[*s.Rid]; ok {
+					node.Power = &power
+				}
+			}
+		}
+	}
+
+	// rooms group devices
+	for id, room := range data.rooms {
+		node := &RoomNode{RoomGet: room, GroupedLight: findGroupedLight(room, data.groupedLights)}
+		t.Rooms[id] = node
+
+		for _, child := range groupChildren(room, ResourceIdentifierRtypeDevice) {
+			if device, ok := t.Devices[child]; ok {
+				device.Room = node
+				node.Devices = append(node.Devices, device)
+			}
+		}
+	}
+
+	// zones group lights, or devices in which case all their lights are part of the zone
+	for id, zone := range data.zones {
+		node := &ZoneNode{RoomGet: zone, GroupedLight: findGroupedLight(zone, data.groupedLights)}
+		t.Zones[id] = node
+
+		var lights []*LightNode
+		for _, child := range groupChildren(zone, ResourceIdentifierRtypeLight) {
+			if light, ok := t.Lights[child]; ok {
+				lights = append(lights, light)
+			}
+		}
+		for _, child := range groupChildren(zone, ResourceIdentifierRtypeDevice) {
+			if device, ok := t.Devices[child]; ok {
+				lights = append(lights, device.Lights...)
+			}
+		}
+
+		seen := make(map[*LightNode]bool)
+		for _, light := range lights {
+			if seen[light] {
+				continue
+			}
+			seen[light] = true
+			light.Zones = append(light.Zones, node)
+			node.Lights = append(node.Lights, light)
+		}
+	}
+
+	return t
+}
+
+// groupChildren returns the IDs of the children of a room or a zone having the given type.
+func groupChildren(group RoomGet, rtype ResourceIdentifierRtype) []string {
+	if group.Children == nil {
+		return nil
+	}
+	var ids []string
+	for _, child := range *group.Children {
+		if child.Rid != nil && child.Rtype != nil && *child.Rtype == rtype {
+			ids = append(ids, *child.Rid)
+		}
+	}
+	return ids
+}
+
+func findGroupedLight(group RoomGet, groupedLights map[string]GroupedLightGet) *GroupedLightGet {
+	if group.Services == nil {
+		return nil
+	}
+	for _, s := range *group.Services {
+		if s.Rid == nil || s.Rtype == nil || *s.Rtype != ResourceIdentifierRtypeGroupedLight {
+			continue
+		}
+		if groupedLight, ok := groupedLights[*s.Rid]; ok {
+			return &groupedLight
+		}
+	}
+	return nil
+}
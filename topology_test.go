@@ -0,0 +1,55 @@
+package openhue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTopology(t *testing.T) {
+	data := topologyData{
+		rooms: map[string]RoomGet{
+			"room-1": fromJSON[RoomGet](t, `{"id": "room-1", "children": [{"rid": "device-1", "rtype": "device"}, {"rid": "device-2", "rtype": "device"}], "services": [{"rid": "gl-1", "rtype": "grouped_light"}]}`),
+		},
+		zones: map[string]RoomGet{
+			"zone-1": fromJSON[RoomGet](t, `{"id": "zone-1", "children": [{"rid": "light-1", "rtype": "light"}]}`),
+		},
+		devices: map[string]DeviceGet{
+			"device-1": fromJSON[DeviceGet](t, `{"id": "device-1", "services": [{"rid": "light-1", "rtype": "light"}, {"rid": "zigbee-1", "rtype": "zigbee_connectivity"}]}`),
+			"device-2": fromJSON[DeviceGet](t, `{"id": "device-2", "services": [{"rid": "motion-1", "rtype": "motion"}, {"rid": "temperature-1", "rtype": "temperature"}, {"rid": "power-1", "rtype": "device_power"}]}`),
+			"device-3": fromJSON[DeviceGet](t, `{"id": "device-3", "services": [{"rid": "button-1", "rtype": "button"}]}`),
+		},
+		lights:        map[string]LightGet{"light-1": fromJSON[LightGet](t, `{"id": "light-1"}`)},
+		groupedLights: map[string]GroupedLightGet{"gl-1": fromJSON[GroupedLightGet](t, `{"id": "gl-1"}`)},
+		motions:       map[string]MotionGet{"motion-1": fromJSON[MotionGet](t, `{"id": "motion-1"}`)},
+		buttons:       map[string]ButtonGet{"button-1": fromJSON[ButtonGet](t, `{"id": "button-1"}`)},
+		temperatures:  map[string]TemperatureGet{"temperature-1": fromJSON[TemperatureGet](t, `{"id": "temperature-1"}`)},
+		powers:        map[string]DevicePowerGet{"power-1": fromJSON[DevicePowerGet](t, `{"id": "power-1"}`)},
+	}
+
+	topology := newTopology(data)
+
+	room := topology.Rooms["room-1"]
+	assert.Len(t, room.Devices, 2)
+	assert.Equal(t, "gl-1", *room.GroupedLight.Id)
+
+	// downwards
+	sensor := topology.Devices["device-2"]
+	assert.Equal(t, "motion-1", *sensor.Motions[0].Id)
+	assert.Equal(t, "temperature-1", *sensor.Temperatures[0].Id)
+	assert.Equal(t, "power-1", *sensor.Power.Id)
+	assert.Equal(t, "button-1", *topology.Devices["device-3"].Buttons[0].Id)
+
+	// upwards
+	assert.Same(t, room, topology.RoomOf("motion-1"))
+	assert.Same(t, room, topology.RoomOf("device-1"))
+	assert.Same(t, sensor, topology.DeviceOf("temperature-1"))
+	assert.Nil(t, topology.RoomOf("button-1"))
+	assert.Nil(t, topology.RoomOf("unknown"))
+
+	light := topology.Lights["light-1"]
+	assert.Same(t, room, light.Room())
+	assert.Equal(t, []*ZoneNode{topology.Zones["zone-1"]}, light.Zones)
+	assert.Equal(t, []*ZoneNode{topology.Zones["zone-1"]}, topology.ZonesOf("device-1"))
+	assert.Equal(t, []*LightNode{light}, topology.Zones["zone-1"].Lights)
+}